@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"errors"
 
 	"github.com/g8rswimmer/go-sfdc/session"
@@ -27,10 +28,16 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 // CreateJob will create a new bulk 2.0 job from the options that where passed.
 // The Job that is returned can be used to upload object data to the Salesforce org.
 func (r *Resource) CreateJob(options Options) (*Job, error) {
+	return r.CreateJobWithContext(context.Background(), options)
+}
+
+// CreateJobWithContext will create a new bulk 2.0 job from the options that where passed
+// using the context for the request.
+func (r *Resource) CreateJobWithContext(ctx context.Context, options Options) (*Job, error) {
 	job := &Job{
 		session: r.session,
 	}
-	if err := job.create(options); err != nil {
+	if err := job.create(ctx, options); err != nil {
 		return nil, err
 	}
 
@@ -39,7 +46,12 @@ func (r *Resource) CreateJob(options Options) (*Job, error) {
 
 // AllJobs will retrieve all of the bulk 2.0 jobs.
 func (r *Resource) AllJobs(parameters Parameters) (*Jobs, error) {
-	jobs, err := newJobs(r.session, parameters)
+	return r.AllJobsWithContext(context.Background(), parameters)
+}
+
+// AllJobsWithContext will retrieve all of the bulk 2.0 jobs using the context for the request.
+func (r *Resource) AllJobsWithContext(ctx context.Context, parameters Parameters) (*Jobs, error) {
+	jobs, err := newJobs(ctx, r.session, parameters)
 	if err != nil {
 		return nil, err
 	}
@@ -12,6 +12,13 @@ type Record interface {
 	InsertNull() bool
 }
 
+// ExplicitNuller is a record that only nulls the fields with a nil value when
+// it inserts nulls.  The other fields that are absent from the record are
+// left empty, instead of being nulled.
+type ExplicitNuller interface {
+	ExplicitNull() bool
+}
+
 // Formatter is the object that will add records for the bulk uploader.
 type Formatter struct {
 	job    *Job
@@ -55,17 +62,20 @@ func (f *Formatter) Add(records ...Record) error {
 		recFields := record.Fields()
 		values := make([]string, len(f.fields))
 		insertNull := record.InsertNull()
+		explicitNull := false
+		if nuller, ok := record.(ExplicitNuller); ok {
+			explicitNull = nuller.ExplicitNull()
+		}
 		for idx, field := range f.fields {
-			if insertNull {
+			value, ok := recFields[field]
+			switch {
+			case ok && value != nil:
+				values[idx] = fmt.Sprintf("%v", value)
+			case insertNull && (ok || explicitNull == false):
 				values[idx] = "#N/A"
-			} else {
+			default:
 				values[idx] = ""
 			}
-			if value, ok := recFields[field]; ok {
-				if value != nil {
-					values[idx] = fmt.Sprintf("%v", value)
-				}
-			}
 		}
 		_, err := f.sb.WriteString(strings.Join(values, f.job.delimiter()))
 		if err != nil {
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -198,3 +200,63 @@ func TestFormatter_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatter_Add_StructRecord(t *testing.T) {
+	type account struct {
+		ID      string `sfdc:"Id,readonly"`
+		Name    string
+		Site    string `sfdc:",omitempty"`
+		Phone   string `sfdc:",null"`
+		Fax     string `sfdc:",null"`
+		Owner   string `sfdc:"Owner.Name"`
+		Website *string
+	}
+	job := &Job{
+		info: Response{
+			ColumnDelimiter: string(Pipe),
+			LineEnding:      string(Linefeed),
+		},
+	}
+	tests := []struct {
+		name  string
+		value *account
+		want  string
+	}{
+		{
+			name: "Explicit Null",
+			value: &account{
+				Name: "Acme",
+				Fax:  "555-1234",
+			},
+			want: "Acme||#N/A|555-1234||\n",
+		},
+		{
+			name: "No Nulls",
+			value: &account{
+				Name:  "Acme",
+				Site:  "HQ",
+				Phone: "555-9876",
+				Fax:   "555-1234",
+			},
+			want: "Acme|HQ|555-9876|555-1234||\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := sfdc.NewStructRecord("Account", tt.value)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			f := &Formatter{
+				job:    job,
+				fields: []string{"Name", "Site", "Phone", "Fax", "Owner.Name", "Website"},
+			}
+			if err := f.Add(record); err != nil {
+				t.Fatalf("Formatter.Add() error = %v", err)
+			}
+			if f.sb.String() != tt.want {
+				t.Errorf("Formatter.Add() = %q, want %q", f.sb.String(), tt.want)
+			}
+		})
+	}
+}
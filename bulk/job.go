@@ -3,6 +3,7 @@ package bulk
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -174,12 +175,12 @@ type Job struct {
 	info    Response
 }
 
-func (j *Job) create(options Options) error {
+func (j *Job) create(ctx context.Context, options Options) error {
 	err := j.formatOptions(&options)
 	if err != nil {
 		return err
 	}
-	j.info, err = j.createCallout(options)
+	j.info, err = j.createCallout(ctx, options)
 	if err != nil {
 		return err
 	}
@@ -211,13 +212,13 @@ func (j *Job) formatOptions(options *Options) error {
 	return nil
 }
 
-func (j *Job) createCallout(options Options) (Response, error) {
+func (j *Job) createCallout(ctx context.Context, options Options) (Response, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint
 	body, err := json.Marshal(options)
 	if err != nil {
 		return Response{}, err
 	}
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return Response{}, err
 	}
@@ -238,18 +239,7 @@ func (j *Job) response(request *http.Request) (Response, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errs []sfdc.Error
-		err = decoder.Decode(&errs)
-		var errMsg error
-		if err == nil {
-			for _, err := range errs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", err.ErrorCode, err.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return Response{}, errMsg
+		return Response{}, sfdc.NewAPIError(response)
 	}
 
 	var value Response
@@ -262,8 +252,13 @@ func (j *Job) response(request *http.Request) (Response, error) {
 
 // Info returns the current job information.
 func (j *Job) Info() (Info, error) {
+	return j.InfoWithContext(context.Background())
+}
+
+// InfoWithContext returns the current job information using the context for the request.
+func (j *Job) InfoWithContext(ctx context.Context) (Info, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return Info{}, err
 	}
@@ -284,18 +279,7 @@ func (j *Job) infoResponse(request *http.Request) (Info, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var errs []sfdc.Error
-		err = decoder.Decode(&errs)
-		var errMsg error
-		if err == nil {
-			for _, err := range errs {
-				errMsg = fmt.Errorf("job err: %s: %s", err.ErrorCode, err.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("job err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return Info{}, errMsg
+		return Info{}, sfdc.NewAPIError(response)
 	}
 
 	var value Info
@@ -306,7 +290,7 @@ func (j *Job) infoResponse(request *http.Request) (Info, error) {
 	return value, nil
 }
 
-func (j *Job) setState(state State) (Response, error) {
+func (j *Job) setState(ctx context.Context, state State) (Response, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID
 	jobState := struct {
 		State string `json:"state"`
@@ -317,7 +301,7 @@ func (j *Job) setState(state State) (Response, error) {
 	if err != nil {
 		return Response{}, err
 	}
-	request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 	if err != nil {
 		return Response{}, err
 	}
@@ -330,18 +314,33 @@ func (j *Job) setState(state State) (Response, error) {
 
 // Close will close the current job.
 func (j *Job) Close() (Response, error) {
-	return j.setState(UpdateComplete)
+	return j.CloseWithContext(context.Background())
+}
+
+// CloseWithContext will close the current job using the context for the request.
+func (j *Job) CloseWithContext(ctx context.Context) (Response, error) {
+	return j.setState(ctx, UpdateComplete)
 }
 
 // Abort will abort the current job.
 func (j *Job) Abort() (Response, error) {
-	return j.setState(Aborted)
+	return j.AbortWithContext(context.Background())
+}
+
+// AbortWithContext will abort the current job using the context for the request.
+func (j *Job) AbortWithContext(ctx context.Context) (Response, error) {
+	return j.setState(ctx, Aborted)
 }
 
 // Delete will delete the current job.
 func (j *Job) Delete() error {
+	return j.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext will delete the current job using the context for the request.
+func (j *Job) DeleteWithContext(ctx context.Context) error {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
@@ -351,17 +350,23 @@ func (j *Job) Delete() error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return errors.New("job error: unable to delete job")
+		return sfdc.NewAPIError(response)
 	}
 	return nil
 }
 
 // Upload will upload data to processing.
 func (j *Job) Upload(body io.Reader) error {
+	return j.UploadWithContext(context.Background(), body)
+}
+
+// UploadWithContext will upload data to processing using the context for the request.
+func (j *Job) UploadWithContext(ctx context.Context, body io.Reader) error {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID + "/batches"
-	request, err := http.NewRequest(http.MethodPut, url, body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	if err != nil {
 		return err
 	}
@@ -372,17 +377,24 @@ func (j *Job) Upload(body io.Reader) error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated {
-		return errors.New("job error: unable to upload job")
+		return sfdc.NewAPIError(response)
 	}
 	return nil
 }
 
 // SuccessfulRecords returns the successful records for the job.
 func (j *Job) SuccessfulRecords() ([]SuccessfulRecord, error) {
+	return j.SuccessfulRecordsWithContext(context.Background())
+}
+
+// SuccessfulRecordsWithContext returns the successful records for the job using the
+// context for the request.
+func (j *Job) SuccessfulRecordsWithContext(ctx context.Context) ([]SuccessfulRecord, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID + "/successfulResults/"
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -395,19 +407,8 @@ func (j *Job) SuccessfulRecords() ([]SuccessfulRecord, error) {
 	}
 
 	if response.StatusCode != http.StatusOK {
-		decoder := json.NewDecoder(response.Body)
 		defer response.Body.Close()
-		var errs []sfdc.Error
-		err = decoder.Decode(&errs)
-		var errMsg error
-		if err == nil {
-			for _, err := range errs {
-				errMsg = fmt.Errorf("job err: %s: %s", err.ErrorCode, err.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("job err: %d %s", response.StatusCode, response.Status)
-		}
-		return nil, errMsg
+		return nil, sfdc.NewAPIError(response)
 	}
 
 	scanner := bufio.NewScanner(response.Body)
@@ -429,6 +430,9 @@ func (j *Job) SuccessfulRecords() ([]SuccessfulRecord, error) {
 	}
 	fields := j.fields(columns, 2)
 	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var record SuccessfulRecord
 		values := strings.Split(scanner.Text(), delimiter)
 		isCreated := strings.Replace(values[createIdx], "\"", "", -1)
@@ -441,14 +445,23 @@ func (j *Job) SuccessfulRecords() ([]SuccessfulRecord, error) {
 		record.Fields = j.record(fields, values[2:])
 		records = append(records, record)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return records, nil
 }
 
 // FailedRecords returns the failed records for the job.
 func (j *Job) FailedRecords() ([]FailedRecord, error) {
+	return j.FailedRecordsWithContext(context.Background())
+}
+
+// FailedRecordsWithContext returns the failed records for the job using the context for
+// the request.
+func (j *Job) FailedRecordsWithContext(ctx context.Context) ([]FailedRecord, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID + "/failedResults/"
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -461,19 +474,8 @@ func (j *Job) FailedRecords() ([]FailedRecord, error) {
 	}
 
 	if response.StatusCode != http.StatusOK {
-		decoder := json.NewDecoder(response.Body)
 		defer response.Body.Close()
-		var errs []sfdc.Error
-		err = decoder.Decode(&errs)
-		var errMsg error
-		if err == nil {
-			for _, err := range errs {
-				errMsg = fmt.Errorf("job err: %s: %s", err.ErrorCode, err.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("job err: %d %s", response.StatusCode, response.Status)
-		}
-		return nil, errMsg
+		return nil, sfdc.NewAPIError(response)
 	}
 
 	scanner := bufio.NewScanner(response.Body)
@@ -495,6 +497,9 @@ func (j *Job) FailedRecords() ([]FailedRecord, error) {
 	}
 	fields := j.fields(columns, 2)
 	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var record FailedRecord
 		values := strings.Split(scanner.Text(), delimiter)
 		record.Error = values[errorIdx]
@@ -502,14 +507,23 @@ func (j *Job) FailedRecords() ([]FailedRecord, error) {
 		record.Fields = j.record(fields, values[2:])
 		records = append(records, record)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return records, nil
 }
 
 // UnprocessedRecords returns the unprocessed records for the job.
 func (j *Job) UnprocessedRecords() ([]UnprocessedRecord, error) {
+	return j.UnprocessedRecordsWithContext(context.Background())
+}
+
+// UnprocessedRecordsWithContext returns the unprocessed records for the job using the
+// context for the request.
+func (j *Job) UnprocessedRecordsWithContext(ctx context.Context) ([]UnprocessedRecord, error) {
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.info.ID + "/unprocessedrecords/"
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -522,19 +536,8 @@ func (j *Job) UnprocessedRecords() ([]UnprocessedRecord, error) {
 	}
 
 	if response.StatusCode != http.StatusOK {
-		decoder := json.NewDecoder(response.Body)
 		defer response.Body.Close()
-		var errs []sfdc.Error
-		err = decoder.Decode(&errs)
-		var errMsg error
-		if err == nil {
-			for _, err := range errs {
-				errMsg = fmt.Errorf("job err: %s: %s", err.ErrorCode, err.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("job err: %d %s", response.StatusCode, response.Status)
-		}
-		return nil, errMsg
+		return nil, sfdc.NewAPIError(response)
 	}
 
 	scanner := bufio.NewScanner(response.Body)
@@ -548,11 +551,17 @@ func (j *Job) UnprocessedRecords() ([]UnprocessedRecord, error) {
 	}
 	fields := j.fields(columns, 0)
 	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		var record UnprocessedRecord
 		values := strings.Split(scanner.Text(), delimiter)
 		record.Fields = j.record(fields, values)
 		records = append(records, record)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return records, nil
 }
@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -549,7 +550,7 @@ func TestJob_createCallout(t *testing.T) {
 				session: tt.fields.session,
 				info:    tt.fields.info,
 			}
-			got, err := j.createCallout(tt.args.options)
+			got, err := j.createCallout(context.Background(), tt.args.options)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Job.createCallout() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -636,7 +637,7 @@ func TestJob_create(t *testing.T) {
 				session: tt.fields.session,
 				info:    tt.fields.info,
 			}
-			if err := j.create(tt.args.options); (err != nil) != tt.wantErr {
+			if err := j.create(context.Background(), tt.args.options); (err != nil) != tt.wantErr {
 				t.Errorf("Job.create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -788,7 +789,7 @@ func TestJob_setState(t *testing.T) {
 				session: tt.fields.session,
 				info:    tt.fields.info,
 			}
-			got, err := j.setState(tt.args.state)
+			got, err := j.setState(context.Background(), tt.args.state)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Job.setState() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1507,3 +1508,59 @@ func TestJob_UnprocessedRecords(t *testing.T) {
 		})
 	}
 }
+
+type mockLineReader struct {
+	lines  []string
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (mock *mockLineReader) Read(p []byte) (int, error) {
+	if mock.reads == len(mock.lines) {
+		return 0, io.EOF
+	}
+	mock.reads++
+	if mock.reads == len(mock.lines) {
+		mock.cancel()
+	}
+	return copy(p, mock.lines[mock.reads-1]), nil
+}
+
+func TestJob_SuccessfulRecordsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	j := &Job{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "Good",
+					Body: ioutil.NopCloser(&mockLineReader{
+						lines: []string{
+							"sf__Created|sf__Id|FirstName|LastName|DOB\n",
+							"true|2345|John|Doe|1/1/1970\n",
+							"true|9876|Jane|Doe|1/1/1980\n",
+						},
+						cancel: cancel,
+					}),
+					Header: make(http.Header),
+				}
+			}),
+		},
+		info: Response{
+			ID:              "1234",
+			ColumnDelimiter: string(Pipe),
+			LineEnding:      string(Linefeed),
+		},
+	}
+
+	got, err := j.SuccessfulRecordsWithContext(ctx)
+	if err != context.Canceled {
+		t.Errorf("Job.SuccessfulRecordsWithContext() error = %v, want %v", err, context.Canceled)
+	}
+	if got != nil {
+		t.Errorf("Job.SuccessfulRecordsWithContext() = %v, want nil", got)
+	}
+}
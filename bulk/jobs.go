@@ -1,9 +1,9 @@
 package bulk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -33,12 +33,12 @@ type Jobs struct {
 	response jobResponse
 }
 
-func newJobs(session session.ServiceFormatter, parameters Parameters) (*Jobs, error) {
+func newJobs(ctx context.Context, session session.ServiceFormatter, parameters Parameters) (*Jobs, error) {
 	j := &Jobs{
 		session: session,
 	}
 	url := session.ServiceURL() + bulk2Endpoint
-	request, err := j.request(url)
+	request, err := j.request(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -67,10 +67,16 @@ func (j *Jobs) Records() []Response {
 
 // Next will retrieve the next batch of job information.
 func (j *Jobs) Next() (*Jobs, error) {
+	return j.NextWithContext(context.Background())
+}
+
+// NextWithContext will retrieve the next batch of job information using the context for
+// the request.
+func (j *Jobs) NextWithContext(ctx context.Context) (*Jobs, error) {
 	if j.Done() == true {
 		return nil, errors.New("jobs: there is no more records")
 	}
-	request, err := j.request(j.response.NextRecordsURL)
+	request, err := j.request(ctx, j.response.NextRecordsURL)
 	if err != nil {
 		return nil, err
 	}
@@ -83,8 +89,8 @@ func (j *Jobs) Next() (*Jobs, error) {
 		response: response,
 	}, nil
 }
-func (j *Jobs) request(url string) (*http.Request, error) {
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+func (j *Jobs) request(ctx context.Context, url string) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -102,18 +108,7 @@ func (j *Jobs) do(request *http.Request) (jobResponse, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var jobsErrs []sfdc.Error
-		err = decoder.Decode(&jobsErrs)
-		var errMsg error
-		if err == nil {
-			for _, jobErr := range jobsErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", jobErr.ErrorCode, jobErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return jobResponse{}, errMsg
+		return jobResponse{}, sfdc.NewAPIError(response)
 	}
 
 	var value jobResponse
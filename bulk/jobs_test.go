@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -228,7 +229,7 @@ func Test_newJobs(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newJobs(tt.args.session, tt.args.parameters)
+			got, err := newJobs(context.Background(), tt.args.session, tt.args.parameters)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("newJobs() error = %v, wantErr %v", err, tt.wantErr)
 				return
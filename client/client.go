@@ -0,0 +1,291 @@
+// Package client provides a single client for all of the Salesforce APIs.
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/bulk"
+	"github.com/g8rswimmer/go-sfdc/composite"
+	"github.com/g8rswimmer/go-sfdc/composite/batch"
+	"github.com/g8rswimmer/go-sfdc/limits"
+	"github.com/g8rswimmer/go-sfdc/session"
+	"github.com/g8rswimmer/go-sfdc/sobject"
+	"github.com/g8rswimmer/go-sfdc/sobject/collections"
+	"github.com/g8rswimmer/go-sfdc/sobject/tree"
+	"github.com/g8rswimmer/go-sfdc/soql"
+)
+
+// SOQLResource is the interface of the soql package's resource.
+type SOQLResource interface {
+	Query(querier soql.QueryFormatter, all bool) (*soql.QueryResult, error)
+	QueryWithContext(ctx context.Context, querier soql.QueryFormatter, all bool) (*soql.QueryResult, error)
+}
+
+// SObjectResources is the interface of the sobject package's resources.
+type SObjectResources interface {
+	Metadata(sobject string) (sobject.MetadataValue, error)
+	MetadataWithContext(ctx context.Context, sobject string) (sobject.MetadataValue, error)
+	Describe(sobject string) (sobject.DescribeValue, error)
+	DescribeWithContext(ctx context.Context, sobject string) (sobject.DescribeValue, error)
+	Insert(inserter sobject.Inserter) (sobject.InsertValue, error)
+	InsertWithContext(ctx context.Context, inserter sobject.Inserter) (sobject.InsertValue, error)
+	Update(updater sobject.Updater) error
+	UpdateWithContext(ctx context.Context, updater sobject.Updater) error
+	Upsert(upserter sobject.Upserter) (sobject.UpsertValue, error)
+	UpsertWithContext(ctx context.Context, upserter sobject.Upserter) (sobject.UpsertValue, error)
+	Delete(deleter sobject.Deleter) error
+	DeleteWithContext(ctx context.Context, deleter sobject.Deleter) error
+	Query(querier sobject.Querier) (*sfdc.Record, error)
+	QueryWithContext(ctx context.Context, querier sobject.Querier) (*sfdc.Record, error)
+	ExternalQuery(querier sobject.ExternalQuerier) (*sfdc.Record, error)
+	ExternalQueryWithContext(ctx context.Context, querier sobject.ExternalQuerier) (*sfdc.Record, error)
+	DeletedRecords(sobject string, startDate, endDate time.Time) (sobject.DeletedRecords, error)
+	DeletedRecordsWithContext(ctx context.Context, sobject string, startDate, endDate time.Time) (sobject.DeletedRecords, error)
+	UpdatedRecords(sobject string, startDate, endDate time.Time) (sobject.UpdatedRecords, error)
+	UpdatedRecordsWithContext(ctx context.Context, sobject string, startDate, endDate time.Time) (sobject.UpdatedRecords, error)
+	GetContent(id string, content sobject.ContentType) ([]byte, error)
+	GetContentWithContext(ctx context.Context, id string, content sobject.ContentType) ([]byte, error)
+}
+
+// CollectionsResource is the interface of the collections package's resource.
+type CollectionsResource interface {
+	Insert(allOrNone bool, records []sobject.Inserter) ([]sobject.InsertValue, error)
+	InsertWithContext(ctx context.Context, allOrNone bool, records []sobject.Inserter) ([]sobject.InsertValue, error)
+	Delete(allOrNone bool, records []string) ([]collections.DeleteValue, error)
+	DeleteWithContext(ctx context.Context, allOrNone bool, records []string) ([]collections.DeleteValue, error)
+	Update(allOrNone bool, records []sobject.Updater) ([]collections.UpdateValue, error)
+	UpdateWithContext(ctx context.Context, allOrNone bool, records []sobject.Updater) ([]collections.UpdateValue, error)
+	Query(sobject string, records []sobject.Querier) ([]*sfdc.Record, error)
+	QueryWithContext(ctx context.Context, sobject string, records []sobject.Querier) ([]*sfdc.Record, error)
+}
+
+// TreeResource is the interface of the tree package's resource.
+type TreeResource interface {
+	Insert(inserter tree.Inserter) (*tree.Value, error)
+	InsertWithContext(ctx context.Context, inserter tree.Inserter) (*tree.Value, error)
+}
+
+// CompositeResource is the interface of the composite package's resource.
+type CompositeResource interface {
+	Retrieve(allOrNone bool, requesters []composite.Subrequester) (composite.Value, error)
+	RetrieveWithContext(ctx context.Context, allOrNone bool, requesters []composite.Subrequester) (composite.Value, error)
+}
+
+// BatchResource is the interface of the batch package's resource.
+type BatchResource interface {
+	Retrieve(haltOnError bool, requesters []batch.Subrequester) (batch.Value, error)
+	RetrieveWithContext(ctx context.Context, haltOnError bool, requesters []batch.Subrequester) (batch.Value, error)
+}
+
+// BulkResource is the interface of the bulk package's resource.
+type BulkResource interface {
+	CreateJob(options bulk.Options) (*bulk.Job, error)
+	CreateJobWithContext(ctx context.Context, options bulk.Options) (*bulk.Job, error)
+	AllJobs(parameters bulk.Parameters) (*bulk.Jobs, error)
+	AllJobsWithContext(ctx context.Context, parameters bulk.Parameters) (*bulk.Jobs, error)
+}
+
+// LimitsResource is the interface of the limits package's resource.
+type LimitsResource interface {
+	Retrieve() (limits.Value, error)
+	RetrieveWithContext(ctx context.Context) (limits.Value, error)
+}
+
+// Resources are the resources of the client.  A nil resource is created from
+// the session the first time that it is used, so a mock resource can be used
+// in place of any of them.
+type Resources struct {
+	SOQL        SOQLResource
+	SObject     SObjectResources
+	Collections CollectionsResource
+	Tree        TreeResource
+	Composite   CompositeResource
+	Batch       BatchResource
+	Bulk        BulkResource
+	Limits      LimitsResource
+}
+
+// Client bundles the Salesforce API resources for a session.  Each resource
+// is created the first time that it is used.
+type Client struct {
+	session     session.ServiceFormatter
+	resources   Resources
+	soql        lazyResource
+	sobject     lazyResource
+	collections lazyResource
+	tree        lazyResource
+	composite   lazyResource
+	batch       lazyResource
+	bulk        lazyResource
+	limits      lazyResource
+}
+
+// lazyResource creates the resource once and keeps its constructor error.
+type lazyResource struct {
+	once     sync.Once
+	resource interface{}
+	err      error
+}
+
+func (l *lazyResource) get(create func() (interface{}, error)) (interface{}, error) {
+	l.once.Do(func() {
+		l.resource, l.err = create()
+	})
+	return l.resource, l.err
+}
+
+// New will open a session with the configuration and return the client.
+func New(config sfdc.Configuration) (*Client, error) {
+	return NewWithContext(context.Background(), config)
+}
+
+// NewWithContext will open a session with the configuration using the context for
+// the authentication request and return the client.
+func NewWithContext(ctx context.Context, config sfdc.Configuration) (*Client, error) {
+	session, err := session.OpenWithContext(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromSession(session)
+}
+
+// NewFromSession will return the client for an existing session.  This allows
+// the client to be used with a mock session in tests.
+func NewFromSession(session session.ServiceFormatter) (*Client, error) {
+	if session == nil {
+		return nil, errors.New("client: session can not be nil")
+	}
+	return NewWithResources(session, Resources{}), nil
+}
+
+// NewWithResources will return the client with the resources, which allows
+// the client to be used with mock resources in tests.  The resources that are
+// nil are created from the session.  The session can be nil when every
+// resource that is used is supplied.
+func NewWithResources(session session.ServiceFormatter, resources Resources) *Client {
+	return &Client{
+		session:   session,
+		resources: resources,
+	}
+}
+
+// Session returns the client's session.
+func (c *Client) Session() session.ServiceFormatter {
+	return c.session
+}
+
+// SOQL returns the SOQL query resource, or the error if it can not be created.
+func (c *Client) SOQL() (SOQLResource, error) {
+	resource, err := c.soql.get(func() (interface{}, error) {
+		if c.resources.SOQL != nil {
+			return c.resources.SOQL, nil
+		}
+		return soql.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(SOQLResource), nil
+}
+
+// SObject returns the SObject resources, or the error if it can not be created.
+func (c *Client) SObject() (SObjectResources, error) {
+	resource, err := c.sobject.get(func() (interface{}, error) {
+		if c.resources.SObject != nil {
+			return c.resources.SObject, nil
+		}
+		return sobject.NewResources(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(SObjectResources), nil
+}
+
+// Collections returns the SObject collections resource, or the error if it can not be created.
+func (c *Client) Collections() (CollectionsResource, error) {
+	resource, err := c.collections.get(func() (interface{}, error) {
+		if c.resources.Collections != nil {
+			return c.resources.Collections, nil
+		}
+		return collections.NewResources(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(CollectionsResource), nil
+}
+
+// Tree returns the SObject tree resource, or the error if it can not be created.
+func (c *Client) Tree() (TreeResource, error) {
+	resource, err := c.tree.get(func() (interface{}, error) {
+		if c.resources.Tree != nil {
+			return c.resources.Tree, nil
+		}
+		return tree.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(TreeResource), nil
+}
+
+// Composite returns the composite resource, or the error if it can not be created.
+func (c *Client) Composite() (CompositeResource, error) {
+	resource, err := c.composite.get(func() (interface{}, error) {
+		if c.resources.Composite != nil {
+			return c.resources.Composite, nil
+		}
+		return composite.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(CompositeResource), nil
+}
+
+// Batch returns the composite batch resource, or the error if it can not be created.
+func (c *Client) Batch() (BatchResource, error) {
+	resource, err := c.batch.get(func() (interface{}, error) {
+		if c.resources.Batch != nil {
+			return c.resources.Batch, nil
+		}
+		return batch.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(BatchResource), nil
+}
+
+// Bulk returns the bulk 2.0 resource, or the error if it can not be created.
+func (c *Client) Bulk() (BulkResource, error) {
+	resource, err := c.bulk.get(func() (interface{}, error) {
+		if c.resources.Bulk != nil {
+			return c.resources.Bulk, nil
+		}
+		return bulk.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(BulkResource), nil
+}
+
+// Limits returns the org limits resource, or the error if it can not be created.
+func (c *Client) Limits() (LimitsResource, error) {
+	resource, err := c.limits.get(func() (interface{}, error) {
+		if c.resources.Limits != nil {
+			return c.resources.Limits, nil
+		}
+		return limits.NewResource(c.session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource.(LimitsResource), nil
+}
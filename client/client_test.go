@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+	"github.com/g8rswimmer/go-sfdc/session"
+)
+
+func TestNewFromSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		session session.ServiceFormatter
+		wantErr bool
+	}{
+		{
+			name:    "Passing",
+			session: &mockSessionFormatter{},
+			wantErr: false,
+		},
+		{
+			name:    "No Session",
+			session: nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromSession(tt.session)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFromSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Session(), tt.session) {
+				t.Errorf("NewFromSession() session = %v, want %v", got.Session(), tt.session)
+			}
+		})
+	}
+}
+
+func TestClient_Resources(t *testing.T) {
+	client, err := NewFromSession(&mockSessionFormatter{
+		url: "https://test.salesforce.com/services/data/v45.0",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	resources := map[string]func() (interface{}, error){
+		"SOQL":        func() (interface{}, error) { return client.SOQL() },
+		"SObject":     func() (interface{}, error) { return client.SObject() },
+		"Collections": func() (interface{}, error) { return client.Collections() },
+		"Tree":        func() (interface{}, error) { return client.Tree() },
+		"Composite":   func() (interface{}, error) { return client.Composite() },
+		"Batch":       func() (interface{}, error) { return client.Batch() },
+		"Bulk":        func() (interface{}, error) { return client.Bulk() },
+		"Limits":      func() (interface{}, error) { return client.Limits() },
+	}
+	for name, resource := range resources {
+		t.Run(name, func(t *testing.T) {
+			first, err := resource()
+			if err != nil {
+				t.Fatalf("Client.%s() error = %v", name, err)
+			}
+			if reflect.ValueOf(first).IsNil() {
+				t.Fatalf("Client.%s() is nil", name)
+			}
+			if second, _ := resource(); second != first {
+				t.Errorf("Client.%s() is not the same resource", name)
+			}
+		})
+	}
+}
+
+type mockSOQLResource struct {
+	SOQLResource
+}
+
+func TestNewWithResources(t *testing.T) {
+	mock := &mockSOQLResource{}
+	client := NewWithResources(nil, Resources{
+		SOQL: mock,
+	})
+
+	resource, err := client.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	if resource != mock {
+		t.Errorf("Client.SOQL() = %v, want %v", resource, mock)
+	}
+
+	// the resources that are not supplied are created from the nil session
+	for idx := 0; idx < 2; idx++ {
+		if _, err := client.SObject(); err == nil {
+			t.Errorf("Client.SObject() expected an error without a session")
+		}
+	}
+}
+
+type mockQuerier struct{}
+
+func (mock *mockQuerier) Format() (string, error) {
+	return "SELECT Name FROM Account", nil
+}
+
+func TestNew(t *testing.T) {
+	httpClient := mockHTTPClient(func(req *http.Request) *http.Response {
+		var resp string
+		switch req.URL.Path {
+		case "/services/oauth2/token":
+			resp = `
+			{
+				"access_token": "token",
+				"instance_url": "https://some.salesforce.instance.com",
+				"id": "https://test.salesforce.com/id/123456789",
+				"token_type": "Bearer",
+				"issued_at": "1553568410028",
+				"signature": "hello"
+			}`
+		case "/services/data/v45.0/query/":
+			resp = `
+			{
+				"done" : true,
+				"totalSize" : 1,
+				"records" : [
+					{
+						"attributes" : {
+							"type" : "Account",
+							"url" : "/services/data/v45.0/sobjects/Account/001D000000IRFmaIAH"
+						},
+						"Name" : "Test 1"
+					}
+				]
+			}`
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "Not Found",
+				Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "Some Status",
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     make(http.Header),
+		}
+	})
+	creds, err := credentials.NewPasswordCredentials(credentials.PasswordCredentials{
+		URL:          "https://login.salesforce.com",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	client, err := NewWithContext(context.Background(), sfdc.Configuration{
+		Credentials: creds,
+		Client:      httpClient,
+		Version:     45,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resource, err := client.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	result, err := resource.Query(&mockQuerier{}, false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	if result.TotalSize() != 1 {
+		t.Errorf("Resource.Query() total size = %d, want 1", result.TotalSize())
+	}
+
+	if _, err := New(sfdc.Configuration{}); err == nil {
+		t.Error("New() expected an error for an empty configuration")
+	}
+}
@@ -2,9 +2,9 @@ package batch
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/g8rswimmer/go-sfdc"
@@ -62,6 +62,12 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 // order of the array is the order in which the subrequests are
 // placed in the composite batch body.
 func (r *Resource) Retrieve(haltOnError bool, requesters []Subrequester) (Value, error) {
+	return r.RetrieveWithContext(context.Background(), haltOnError, requesters)
+}
+
+// RetrieveWithContext will retrieve the responses to a composite batch requests using
+// the context for the request.
+func (r *Resource) RetrieveWithContext(ctx context.Context, haltOnError bool, requesters []Subrequester) (Value, error) {
 	if requesters == nil {
 		return Value{}, errors.New("composite subrequests: requesters can not nil")
 	}
@@ -77,7 +83,7 @@ func (r *Resource) Retrieve(haltOnError bool, requesters []Subrequester) (Value,
 
 	url := r.session.ServiceURL() + endpoint
 
-	request, err := http.NewRequest(http.MethodPost, url, body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 
 	if err != nil {
 		return Value{}, err
@@ -97,18 +103,7 @@ func (r *Resource) Retrieve(haltOnError bool, requesters []Subrequester) (Value,
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var insertErrs []sfdc.Error
-		err = decoder.Decode(&insertErrs)
-		var errMsg error
-		if err == nil {
-			for _, insertErr := range insertErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", insertErr.ErrorCode, insertErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return Value{}, errMsg
+		return Value{}, sfdc.NewAPIError(response)
 	}
 
 	var value Value
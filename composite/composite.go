@@ -2,9 +2,9 @@ package composite
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/g8rswimmer/go-sfdc"
@@ -69,6 +69,12 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 
 // Retrieve will retrieve the responses to a composite requests.
 func (r *Resource) Retrieve(allOrNone bool, requesters []Subrequester) (Value, error) {
+	return r.RetrieveWithContext(context.Background(), allOrNone, requesters)
+}
+
+// RetrieveWithContext will retrieve the responses to a composite requests using the
+// context for the request.
+func (r *Resource) RetrieveWithContext(ctx context.Context, allOrNone bool, requesters []Subrequester) (Value, error) {
 	if requesters == nil {
 		return Value{}, errors.New("composite subrequests: requesters can not nil")
 	}
@@ -84,7 +90,7 @@ func (r *Resource) Retrieve(allOrNone bool, requesters []Subrequester) (Value, e
 
 	url := r.session.ServiceURL() + endpoint
 
-	request, err := http.NewRequest(http.MethodPost, url, body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 
 	if err != nil {
 		return Value{}, err
@@ -104,18 +110,7 @@ func (r *Resource) Retrieve(allOrNone bool, requesters []Subrequester) (Value, e
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var insertErrs []sfdc.Error
-		err = decoder.Decode(&insertErrs)
-		var errMsg error
-		if err == nil {
-			for _, insertErr := range insertErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", insertErr.ErrorCode, insertErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return Value{}, errMsg
+		return Value{}, sfdc.NewAPIError(response)
 	}
 
 	var value Value
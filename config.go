@@ -13,8 +13,20 @@ import (
 // Client is the HTTP client that will be used.
 //
 // Version is the Salesforce version for the APIs.
+//
+// VersionPolicy is how the version is checked when the session is opened.
+// The default is to use the version as configured.
+//
+// RetryPolicy is the policy for retrying transient failures.  If nil,
+// requests are not retried.
+//
+// Middleware is the chain that each API request and response flows
+// through.  This field is optional.
 type Configuration struct {
-	Credentials *credentials.Credentials
-	Client      *http.Client
-	Version     int
+	Credentials   *credentials.Credentials
+	Client        *http.Client
+	Version       int
+	VersionPolicy VersionPolicy
+	RetryPolicy   *RetryPolicy
+	Middleware    []Middleware
 }
@@ -0,0 +1,270 @@
+package credentials
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables that are read by the environment source.
+const (
+	EnvURL            = "SF_URL"
+	EnvUsername       = "SF_USERNAME"
+	EnvPassword       = "SF_PASSWORD"
+	EnvClientID       = "SF_CLIENT_ID"
+	EnvClientSecret   = "SF_CLIENT_SECRET"
+	EnvRefreshToken   = "SF_REFRESH_TOKEN"
+	EnvPrivateKey     = "SF_PRIVATE_KEY"
+	EnvPrivateKeyFile = "SF_PRIVATE_KEY_FILE"
+)
+
+// ErrNotConfigured is wrapped by the error of a source that has nothing to
+// load.  The chain's error wraps it when none of the sources are configured.
+var ErrNotConfigured = errors.New("not configured")
+
+// Config is the credential configuration that is loaded from a source.  The
+// grant type is decided by the fields that are present, in the following order.
+//
+// JWT bearer is used when the PrivateKey or PrivateKeyFile is present.
+//
+// Password is used when the Password is present.
+//
+// Refresh token is used when the RefreshToken is present.
+//
+// Client credentials are used when only the ClientSecret is present.
+type Config struct {
+	URL            string `json:"url" yaml:"url"`
+	Username       string `json:"username" yaml:"username"`
+	Password       string `json:"password" yaml:"password"`
+	ClientID       string `json:"client_id" yaml:"client_id"`
+	ClientSecret   string `json:"client_secret" yaml:"client_secret"`
+	RefreshToken   string `json:"refresh_token" yaml:"refresh_token"`
+	PrivateKey     string `json:"private_key" yaml:"private_key"`
+	PrivateKeyFile string `json:"private_key_file" yaml:"private_key_file"`
+}
+
+// Source is a place that credentials are able to be loaded from.
+//
+// Name describes the source for error messages.
+//
+// Load will return the credentials from the source.
+type Source interface {
+	Name() string
+	Load() (*Credentials, error)
+}
+
+type environmentSource struct{}
+
+type fileSource struct {
+	path string
+}
+
+type keyFileSource struct {
+	path   string
+	config Config
+}
+
+// Credentials will create the credentials from the configuration.
+func (config Config) Credentials() (*Credentials, error) {
+	switch {
+	case config.PrivateKey != "" || config.PrivateKeyFile != "":
+		var key *rsa.PrivateKey
+		var err error
+		if config.PrivateKey != "" {
+			key, err = ParsePrivateKey([]byte(config.PrivateKey))
+		} else {
+			key, err = LoadPrivateKey(config.PrivateKeyFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return NewJWTCredentials(JwtCredentials{
+			URL:            config.URL,
+			ClientId:       config.ClientID,
+			ClientUsername: config.Username,
+			ClientKey:      key,
+		})
+	case config.Password != "":
+		return NewPasswordCredentials(PasswordCredentials{
+			URL:          config.URL,
+			Username:     config.Username,
+			Password:     config.Password,
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+		})
+	case config.RefreshToken != "":
+		return NewRefreshTokenCredentials(RefreshTokenCredentials{
+			URL:          config.URL,
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RefreshToken: config.RefreshToken,
+		})
+	case config.ClientSecret != "":
+		return NewClientCredentials(ClientCredentials{
+			URL:          config.URL,
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+		})
+	}
+	return nil, errors.New("credentials: configuration does not have a password, refresh token, client secret or private key")
+}
+
+// ParsePrivateKey will parse a PEM encoded RSA private key.
+func ParsePrivateKey(pem []byte) (*rsa.PrivateKey, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: private key %w", err)
+	}
+	return key, nil
+}
+
+// LoadPrivateKey will read and parse a PEM encoded RSA private key file.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
+	}
+	return ParsePrivateKey(pem)
+}
+
+// Environment returns the source that loads the configuration from
+// the SF_ environment variables.
+func Environment() Source {
+	return environmentSource{}
+}
+
+func (source environmentSource) Name() string {
+	return "environment"
+}
+
+func (source environmentSource) Load() (*Credentials, error) {
+	config := Config{
+		URL:            os.Getenv(EnvURL),
+		Username:       os.Getenv(EnvUsername),
+		Password:       os.Getenv(EnvPassword),
+		ClientID:       os.Getenv(EnvClientID),
+		ClientSecret:   os.Getenv(EnvClientSecret),
+		RefreshToken:   os.Getenv(EnvRefreshToken),
+		PrivateKey:     os.Getenv(EnvPrivateKey),
+		PrivateKeyFile: os.Getenv(EnvPrivateKeyFile),
+	}
+	if config == (Config{}) {
+		return nil, fmt.Errorf("%w, %s is not set", ErrNotConfigured, EnvURL)
+	}
+	return config.Credentials()
+}
+
+// File returns the source that loads the configuration from a JSON or YAML
+// file with the Config keys, like client_id.  A file with the .yaml or .yml
+// extension is decoded as YAML and any other file as JSON.  A relative private
+// key file is relative to the configuration file.
+func File(path string) Source {
+	return fileSource{
+		path: path,
+	}
+}
+
+func (source fileSource) Name() string {
+	return "file " + source.path
+}
+
+func (source fileSource) Load() (*Credentials, error) {
+	data, err := ioutil.ReadFile(source.path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w, file does not exist", ErrNotConfigured)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	switch strings.ToLower(filepath.Ext(source.path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("credentials: file is not a YAML configuration %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("credentials: file is not a JSON configuration %w", err)
+		}
+	}
+
+	if config.PrivateKeyFile != "" && filepath.IsAbs(config.PrivateKeyFile) == false {
+		config.PrivateKeyFile = filepath.Join(filepath.Dir(source.path), config.PrivateKeyFile)
+	}
+	return config.Credentials()
+}
+
+// KeyFile returns the source that loads a JWT bearer credential from a
+// PEM encoded private key file.  The configuration supplies the URL, client
+// ID and username.
+func KeyFile(path string, config Config) Source {
+	return keyFileSource{
+		path:   path,
+		config: config,
+	}
+}
+
+func (source keyFileSource) Name() string {
+	return "key file " + source.path
+}
+
+func (source keyFileSource) Load() (*Credentials, error) {
+	if _, err := os.Stat(source.path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w, file does not exist", ErrNotConfigured)
+	}
+	config := source.config
+	config.PrivateKey = ""
+	config.PrivateKeyFile = source.path
+	return config.Credentials()
+}
+
+// NewChain will try each of the sources in order and return the first valid
+// credentials.  If none of the sources are valid, the error will explain
+// each of the sources that were tried.  If none of the sources are
+// configured, the error wraps ErrNotConfigured.
+func NewChain(sources ...Source) (*Credentials, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("credentials chain: there are no sources")
+	}
+	tried := make([]string, len(sources))
+	configured := false
+	for idx, source := range sources {
+		creds, err := source.Load()
+		if err == nil {
+			return creds, nil
+		}
+		if errors.Is(err, ErrNotConfigured) == false {
+			configured = true
+		}
+		tried[idx] = fmt.Sprintf("%s: %s", source.Name(), err.Error())
+	}
+	if configured == false {
+		return nil, fmt.Errorf("credentials chain: %w (%s)", ErrNotConfigured, strings.Join(tried, "; "))
+	}
+	return nil, fmt.Errorf("credentials chain: no valid credentials (%s)", strings.Join(tried, "; "))
+}
+
+// NewDefaultChain will try the sources in the default order: the environment,
+// the JSON configuration file and then the private key file with the
+// configuration.
+func NewDefaultChain(file, keyFile string, config Config) (*Credentials, error) {
+	return NewChain(DefaultChain(file, keyFile, config)...)
+}
+
+// DefaultChain returns the sources of the default chain in order.
+func DefaultChain(file, keyFile string, config Config) []Source {
+	return []Source{
+		Environment(),
+		File(file),
+		KeyFile(keyFile, config),
+	}
+}
@@ -0,0 +1,24 @@
+package credentials
+
+import (
+	"io"
+	"net/url"
+	"strings"
+)
+
+type clientProvider struct {
+	creds ClientCredentials
+}
+
+func (provider *clientProvider) Retrieve() (io.Reader, error) {
+	form := url.Values{}
+	form.Add("grant_type", string(clientGrantType))
+	form.Add("client_id", provider.creds.ClientID)
+	form.Add("client_secret", provider.creds.ClientSecret)
+
+	return strings.NewReader(form.Encode()), nil
+}
+
+func (provider *clientProvider) URL() string {
+	return provider.creds.URL
+}
@@ -0,0 +1,173 @@
+package credentials
+
+import (
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mockClientRetriveReader(creds ClientCredentials) io.Reader {
+	form := url.Values{}
+	form.Add("grant_type", string(clientGrantType))
+	form.Add("client_id", creds.ClientID)
+	form.Add("client_secret", creds.ClientSecret)
+
+	return strings.NewReader(form.Encode())
+}
+
+func Test_clientProvider_Retrieve(t *testing.T) {
+	type fields struct {
+		creds ClientCredentials
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    io.Reader
+		wantErr bool
+	}{
+		{
+			name: "Client Retriever",
+			fields: fields{
+				creds: ClientCredentials{
+					URL:          "https://mydomain.my.salesforce.com",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+				},
+			},
+			want: mockClientRetriveReader(ClientCredentials{
+				URL:          "https://mydomain.my.salesforce.com",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+			}),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &clientProvider{
+				creds: tt.fields.creds,
+			}
+			got, err := provider.Retrieve()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("clientProvider.Retrieve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clientProvider.Retrieve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_clientProvider_URL(t *testing.T) {
+	type fields struct {
+		creds ClientCredentials
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Client URL",
+			fields: fields{
+				creds: ClientCredentials{
+					URL:          "https://mydomain.my.salesforce.com",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+				},
+			},
+			want: "https://mydomain.my.salesforce.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &clientProvider{
+				creds: tt.fields.creds,
+			}
+			if got := provider.URL(); got != tt.want {
+				t.Errorf("clientProvider.URL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientCredentials(t *testing.T) {
+	type args struct {
+		creds ClientCredentials
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Credentials
+		wantErr bool
+	}{
+		{
+			name: "Client Credentials",
+			args: args{
+				creds: ClientCredentials{
+					URL:          "https://mydomain.my.salesforce.com",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+				},
+			},
+			want: &Credentials{
+				provider: &clientProvider{
+					creds: ClientCredentials{
+						URL:          "https://mydomain.my.salesforce.com",
+						ClientID:     "some client id",
+						ClientSecret: "shhhh its a secret",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "No URL",
+			args: args{
+				creds: ClientCredentials{
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "No client ID",
+			args: args{
+				creds: ClientCredentials{
+					URL:          "https://mydomain.my.salesforce.com",
+					ClientSecret: "shhhh its a secret",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "No client secret",
+			args: args{
+				creds: ClientCredentials{
+					URL:      "https://mydomain.my.salesforce.com",
+					ClientID: "some client id",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewClientCredentials(tt.args.creds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClientCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewClientCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,10 +27,47 @@ type PasswordCredentials struct {
 }
 
 type JwtCredentials struct {
-	URL				string
-	ClientId 		string // the client id as defined in the connected app in SalesForce
-	ClientUsername 	string
-	ClientKey 		*rsa.PrivateKey  // the client RSA key uploaded for authentication in the ConnectedApp
+	URL            string
+	ClientId       string // the client id as defined in the connected app in SalesForce
+	ClientUsername string
+	ClientKey      *rsa.PrivateKey // the client RSA key uploaded for authentication in the ConnectedApp
+}
+
+// RefreshTokenCredentials is a structure for the OAuth credentials
+// that are needed to authenticate with a refresh token.
+//
+// URL is the login URL used, examples would be https://test.salesforce.com or https://login.salesforce.com
+//
+// ClientID is the client ID from the connected application.
+//
+// ClientSecret is the client secret from the connected application.  This is
+// optional when the connected application does not require the secret.
+//
+// RefreshToken is the refresh token issued to the connected application.
+//
+// OnRotate is called when Salesforce issues a new refresh token.  This allows
+// the new refresh token to be persisted.  This field is optional.
+type RefreshTokenCredentials struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	OnRotate     func(refreshToken string) error
+}
+
+// ClientCredentials is a structure for the OAuth credentials
+// that are needed to authenticate with the client credentials flow.  The
+// connected application's run as user is used for the session.
+//
+// URL is the org's My Domain URL, an example would be https://mydomain.my.salesforce.com
+//
+// ClientID is the client ID from the connected application.
+//
+// ClientSecret is the client secret from the connected application.
+type ClientCredentials struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
 }
 
 // Credentials is the structure that contains all of the
@@ -50,11 +87,21 @@ type Provider interface {
 	URL() string
 }
 
+// Rotator is the interface for providers that are able to replace
+// their refresh token with the one returned from the session endpoint.
+//
+// Rotate will replace the provider's refresh token.
+type Rotator interface {
+	Rotate(refreshToken string) error
+}
+
 type grantType string
 
 const (
 	passwordGrantType grantType = "password"
-	jwtGrantType grantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	jwtGrantType      grantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	refreshGrantType  grantType = "refresh_token"
+	clientGrantType   grantType = "client_credentials"
 )
 
 // Retrieve will return the reader for the HTTP request body.
@@ -67,6 +114,15 @@ func (creds *Credentials) URL() string {
 	return creds.provider.URL()
 }
 
+// Rotate will replace the refresh token of the provider.  If the provider
+// does not support refresh tokens, the refresh token is ignored.
+func (creds *Credentials) Rotate(refreshToken string) error {
+	if rotator, ok := creds.provider.(Rotator); ok {
+		return rotator.Rotate(refreshToken)
+	}
+	return nil
+}
+
 // NewCredentials will create a credential with the custom provider.
 func NewCredentials(provider Provider) (*Credentials, error) {
 	if provider == nil {
@@ -91,7 +147,9 @@ func NewPasswordCredentials(creds PasswordCredentials) (*Credentials, error) {
 
 // NewJWTCredentials weill create a credntial with all required info about generating a JWT claims parameter
 func NewJWTCredentials(creds JwtCredentials) (*Credentials, error) {
-	if err := validateJWTCredentials(creds); err != nil {return nil, err}
+	if err := validateJWTCredentials(creds); err != nil {
+		return nil, err
+	}
 	return &Credentials{
 		provider: &jwtProvider{
 			creds: creds,
@@ -99,6 +157,30 @@ func NewJWTCredentials(creds JwtCredentials) (*Credentials, error) {
 	}, nil
 }
 
+// NewRefreshTokenCredentials will create a credential with the refresh token credentials.
+func NewRefreshTokenCredentials(creds RefreshTokenCredentials) (*Credentials, error) {
+	if err := validateRefreshTokenCredentials(creds); err != nil {
+		return nil, err
+	}
+	return &Credentials{
+		provider: &refreshTokenProvider{
+			creds: creds,
+		},
+	}, nil
+}
+
+// NewClientCredentials will create a credential with the client credentials.
+func NewClientCredentials(creds ClientCredentials) (*Credentials, error) {
+	if err := validateClientCredentials(creds); err != nil {
+		return nil, err
+	}
+	return &Credentials{
+		provider: &clientProvider{
+			creds: creds,
+		},
+	}, nil
+}
+
 func validatePasswordCredentials(cred PasswordCredentials) error {
 	switch {
 	case len(cred.URL) == 0:
@@ -128,4 +210,28 @@ func validateJWTCredentials(cred JwtCredentials) error {
 	}
 	return nil
 
-}
\ No newline at end of file
+}
+
+func validateRefreshTokenCredentials(cred RefreshTokenCredentials) error {
+	switch {
+	case len(cred.URL) == 0:
+		return errors.New("credentials: refresh token credential's URL can not be empty")
+	case len(cred.ClientID) == 0:
+		return errors.New("credentials: refresh token credential's client ID can not be empty")
+	case len(cred.RefreshToken) == 0:
+		return errors.New("credentials: refresh token credential's refresh token can not be empty")
+	}
+	return nil
+}
+
+func validateClientCredentials(cred ClientCredentials) error {
+	switch {
+	case len(cred.URL) == 0:
+		return errors.New("credentials: client credential's URL can not be empty")
+	case len(cred.ClientID) == 0:
+		return errors.New("credentials: client credential's client ID can not be empty")
+	case len(cred.ClientSecret) == 0:
+		return errors.New("credentials: client credential's client secret can not be empty")
+	}
+	return nil
+}
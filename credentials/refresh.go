@@ -0,0 +1,49 @@
+package credentials
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type refreshTokenProvider struct {
+	creds RefreshTokenCredentials
+	mutex sync.RWMutex
+}
+
+func (provider *refreshTokenProvider) Retrieve() (io.Reader, error) {
+	provider.mutex.RLock()
+	defer provider.mutex.RUnlock()
+
+	form := url.Values{}
+	form.Add("grant_type", string(refreshGrantType))
+	form.Add("refresh_token", provider.creds.RefreshToken)
+	form.Add("client_id", provider.creds.ClientID)
+	if provider.creds.ClientSecret != "" {
+		form.Add("client_secret", provider.creds.ClientSecret)
+	}
+
+	return strings.NewReader(form.Encode()), nil
+}
+
+func (provider *refreshTokenProvider) URL() string {
+	return provider.creds.URL
+}
+
+// Rotate will replace the refresh token when Salesforce issues a new one.
+func (provider *refreshTokenProvider) Rotate(refreshToken string) error {
+	provider.mutex.Lock()
+	if refreshToken == "" || refreshToken == provider.creds.RefreshToken {
+		provider.mutex.Unlock()
+		return nil
+	}
+	provider.creds.RefreshToken = refreshToken
+	onRotate := provider.creds.OnRotate
+	provider.mutex.Unlock()
+
+	if onRotate != nil {
+		return onRotate(refreshToken)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package credentials
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mockRefreshRetriveReader(creds RefreshTokenCredentials) io.Reader {
+	form := url.Values{}
+	form.Add("grant_type", string(refreshGrantType))
+	form.Add("refresh_token", creds.RefreshToken)
+	form.Add("client_id", creds.ClientID)
+	if creds.ClientSecret != "" {
+		form.Add("client_secret", creds.ClientSecret)
+	}
+
+	return strings.NewReader(form.Encode())
+}
+
+func Test_refreshTokenProvider_Retrieve(t *testing.T) {
+	type fields struct {
+		creds RefreshTokenCredentials
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    io.Reader
+		wantErr bool
+	}{
+		{
+			name: "Refresh Retriever",
+			fields: fields{
+				creds: RefreshTokenCredentials{
+					URL:          "http://test.refresh.session",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+					RefreshToken: "some refresh token",
+				},
+			},
+			want: mockRefreshRetriveReader(RefreshTokenCredentials{
+				URL:          "http://test.refresh.session",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+				RefreshToken: "some refresh token",
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Refresh Retriever No Secret",
+			fields: fields{
+				creds: RefreshTokenCredentials{
+					URL:          "http://test.refresh.session",
+					ClientID:     "some client id",
+					RefreshToken: "some refresh token",
+				},
+			},
+			want: mockRefreshRetriveReader(RefreshTokenCredentials{
+				URL:          "http://test.refresh.session",
+				ClientID:     "some client id",
+				RefreshToken: "some refresh token",
+			}),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &refreshTokenProvider{
+				creds: tt.fields.creds,
+			}
+			got, err := provider.Retrieve()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("refreshTokenProvider.Retrieve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("refreshTokenProvider.Retrieve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_refreshTokenProvider_Rotate(t *testing.T) {
+	tests := []struct {
+		name         string
+		refreshToken string
+		rotateErr    error
+		want         string
+		wantRotated  []string
+		wantErr      bool
+	}{
+		{
+			name:         "Rotated",
+			refreshToken: "new refresh token",
+			want:         "new refresh token",
+			wantRotated:  []string{"new refresh token"},
+		},
+		{
+			name:         "Same Token",
+			refreshToken: "some refresh token",
+			want:         "some refresh token",
+		},
+		{
+			name:         "Empty Token",
+			refreshToken: "",
+			want:         "some refresh token",
+		},
+		{
+			name:         "Persist Error",
+			refreshToken: "new refresh token",
+			rotateErr:    errors.New("unable to persist"),
+			want:         "new refresh token",
+			wantRotated:  []string{"new refresh token"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rotated []string
+			creds, err := NewRefreshTokenCredentials(RefreshTokenCredentials{
+				URL:          "http://test.refresh.session",
+				ClientID:     "some client id",
+				RefreshToken: "some refresh token",
+				OnRotate: func(refreshToken string) error {
+					rotated = append(rotated, refreshToken)
+					return tt.rotateErr
+				},
+			})
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := creds.Rotate(tt.refreshToken); (err != nil) != tt.wantErr {
+				t.Errorf("Credentials.Rotate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rotated, tt.wantRotated) {
+				t.Errorf("Credentials.Rotate() persisted = %v, want %v", rotated, tt.wantRotated)
+			}
+			reader, err := creds.Retrieve()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if !reflect.DeepEqual(reader, mockRefreshRetriveReader(RefreshTokenCredentials{
+				ClientID:     "some client id",
+				RefreshToken: tt.want,
+			})) {
+				t.Errorf("Credentials.Rotate() refresh token not %s", tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRefreshTokenCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   RefreshTokenCredentials
+		wantErr bool
+	}{
+		{
+			name: "Refresh Token Credentials",
+			creds: RefreshTokenCredentials{
+				URL:          "http://test.refresh.session",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+				RefreshToken: "some refresh token",
+			},
+			wantErr: false,
+		},
+		{
+			name: "No URL",
+			creds: RefreshTokenCredentials{
+				ClientID:     "some client id",
+				RefreshToken: "some refresh token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "No client ID",
+			creds: RefreshTokenCredentials{
+				URL:          "http://test.refresh.session",
+				RefreshToken: "some refresh token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "No refresh token",
+			creds: RefreshTokenCredentials{
+				URL:      "http://test.refresh.session",
+				ClientID: "some client id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRefreshTokenCredentials(tt.creds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRefreshTokenCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.URL() != tt.creds.URL {
+				t.Errorf("NewRefreshTokenCredentials() URL = %v, want %v", got.URL(), tt.creds.URL)
+			}
+		})
+	}
+}
@@ -1,10 +1,168 @@
 package sfdc
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
 )
 
+// Code is a Salesforce error code.  The code can be used with errors.Is
+// to check if an API error contains the code.
+type Code string
+
+// The common Salesforce error codes.
+const (
+	ErrInvalidSessionID     Code = "INVALID_SESSION_ID"
+	ErrRequestLimitExceeded Code = "REQUEST_LIMIT_EXCEEDED"
+	ErrEntityIsDeleted      Code = "ENTITY_IS_DELETED"
+	ErrDuplicateValue       Code = "DUPLICATE_VALUE"
+	ErrNotFound             Code = "NOT_FOUND"
+	ErrInvalidField         Code = "INVALID_FIELD"
+	ErrMalformedQuery       Code = "MALFORMED_QUERY"
+	ErrInvalidGrant         Code = "invalid_grant"
+)
+
+// maxErrorBody is the largest response body that is read for an API error.
+const maxErrorBody = 1 << 20
+
+// Error returns the error code.
+func (c Code) Error() string {
+	return string(c)
+}
+
+// APIError is the error returned when a Salesforce API response is not
+// successful.
+//
+// StatusCode and Status are from the HTTP response.
+//
+// Method and URL are from the HTTP request.
+//
+// RequestID is the Salesforce request ID, if it was returned.
+//
+// Errors are all of the errors from the response body.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+	RequestID  string
+	Errors     []Error
+}
+
+// oauthError is the error body returned by the OAuth endpoints.
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// NewAPIError will create the API error from the HTTP response.  The response
+// body is read, but it is not closed.
+func NewAPIError(response *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Status:     strings.TrimPrefix(response.Status, strconv.Itoa(response.StatusCode)+" "),
+		RequestID:  response.Header.Get("X-Sfdc-Request-Id"),
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = response.Header.Get("X-Request-Id")
+	}
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		if response.Request.URL != nil {
+			apiErr.URL = response.Request.URL.String()
+		}
+	}
+	if response.Body == nil {
+		return apiErr
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	if err != nil {
+		return apiErr
+	}
+	apiErr.Errors = decodeErrors(body)
+	return apiErr
+}
+
+func decodeErrors(body []byte) []Error {
+	body = bytes.TrimSpace(body)
+	switch {
+	case len(body) == 0:
+		return nil
+	case body[0] == '[':
+		var errs []Error
+		if err := json.Unmarshal(body, &errs); err == nil {
+			return errs
+		}
+	case body[0] == '{':
+		var oauthErr oauthError
+		if err := json.Unmarshal(body, &oauthErr); err == nil && oauthErr.Error != "" {
+			return []Error{
+				{
+					ErrorCode: oauthErr.Error,
+					Message:   oauthErr.Description,
+				},
+			}
+		}
+		var apiErr Error
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.ErrorCode != "" {
+			return []Error{apiErr}
+		}
+	}
+	return nil
+}
+
+// Error returns the request, status and each of the Salesforce errors.
+func (e *APIError) Error() string {
+	var builder strings.Builder
+	builder.WriteString("sfdc: ")
+	if e.Method != "" {
+		builder.WriteString(e.Method + " ")
+	}
+	if e.URL != "" {
+		builder.WriteString(e.URL + " ")
+	}
+	builder.WriteString(fmt.Sprintf("%d %s", e.StatusCode, e.Status))
+	for idx, err := range e.Errors {
+		if idx == 0 {
+			builder.WriteString(": ")
+		} else {
+			builder.WriteString("; ")
+		}
+		builder.WriteString(err.ErrorCode)
+		if err.Message != "" {
+			builder.WriteString(" " + err.Message)
+		}
+	}
+	return builder.String()
+}
+
+// Is will return true if the target is a Code that one of the Salesforce
+// errors has.
+func (e *APIError) Is(target error) bool {
+	code, ok := target.(Code)
+	if ok == false {
+		return false
+	}
+	return e.HasCode(code)
+}
+
+// HasCode will return true if one of the Salesforce errors has the code.
+func (e *APIError) HasCode(code Code) bool {
+	for _, err := range e.Errors {
+		if err.ErrorCode == string(code) {
+			return true
+		}
+	}
+	return false
+}
+
 // Error is the error structure defined by the Salesforce API.
 type Error struct {
 	ErrorCode string   `json:"errorCode"`
@@ -1,6 +1,14 @@
 package sfdc
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestError_UnmarshalJSON(t *testing.T) {
 	type fields struct {
@@ -70,3 +78,184 @@ func TestError_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAPIError(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://test.salesforce.com/services/data/v45.0/sobjects/Account", nil)
+	tests := []struct {
+		name     string
+		response *http.Response
+		want     *APIError
+	}{
+		{
+			name: "Error Array",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 Bad Request",
+				Header: http.Header{
+					"X-Sfdc-Request-Id": []string{"request-1"},
+				},
+				Body: ioutil.NopCloser(strings.NewReader(`
+				[
+					{
+						"fields" : [ "Name" ],
+						"message" : "Required fields are missing: [Name]",
+						"errorCode" : "REQUIRED_FIELD_MISSING"
+					},
+					{
+						"message" : "duplicate value found",
+						"errorCode" : "DUPLICATE_VALUE"
+					}
+				]`)),
+				Request: request,
+			},
+			want: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Status:     "Bad Request",
+				Method:     http.MethodPost,
+				URL:        "https://test.salesforce.com/services/data/v45.0/sobjects/Account",
+				RequestID:  "request-1",
+				Errors: []Error{
+					{
+						ErrorCode: "REQUIRED_FIELD_MISSING",
+						Message:   "Required fields are missing: [Name]",
+						Fields:    []string{"Name"},
+					},
+					{
+						ErrorCode: "DUPLICATE_VALUE",
+						Message:   "duplicate value found",
+					},
+				},
+			},
+		},
+		{
+			name: "OAuth Error",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 Bad Request",
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"error":"invalid_grant","error_description":"authentication failure"}`)),
+			},
+			want: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Status:     "Bad Request",
+				Errors: []Error{
+					{
+						ErrorCode: "invalid_grant",
+						Message:   "authentication failure",
+					},
+				},
+			},
+		},
+		{
+			name: "Error Object",
+			response: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}`)),
+			},
+			want: &APIError{
+				StatusCode: http.StatusNotFound,
+				Status:     "Not Found",
+				Errors: []Error{
+					{
+						ErrorCode: "NOT_FOUND",
+						Message:   "The requested resource does not exist",
+					},
+				},
+			},
+		},
+		{
+			name: "Not JSON",
+			response: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Status:     "Internal Server Error",
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(strings.NewReader(`<html>oops</html>`)),
+			},
+			want: &APIError{
+				StatusCode: http.StatusInternalServerError,
+				Status:     "Internal Server Error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAPIError(tt.response); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAPIError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	apiErr := &APIError{
+		StatusCode: http.StatusBadRequest,
+		Status:     "Bad Request",
+		Method:     http.MethodPost,
+		URL:        "https://test.salesforce.com/services/data/v45.0/sobjects/Account",
+		Errors: []Error{
+			{
+				ErrorCode: "REQUIRED_FIELD_MISSING",
+				Message:   "Required fields are missing: [Name]",
+			},
+			{
+				ErrorCode: "DUPLICATE_VALUE",
+				Message:   "duplicate value found",
+			},
+		},
+	}
+	want := "sfdc: POST https://test.salesforce.com/services/data/v45.0/sobjects/Account 400 Bad Request: REQUIRED_FIELD_MISSING Required fields are missing: [Name]; DUPLICATE_VALUE duplicate value found"
+	if got := apiErr.Error(); got != want {
+		t.Errorf("APIError.Error() = %s, want %s", got, want)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	var err error = fmt.Errorf("insert: %w", &APIError{
+		StatusCode: http.StatusBadRequest,
+		Status:     "Bad Request",
+		Errors: []Error{
+			{
+				ErrorCode: "DUPLICATE_VALUE",
+				Message:   "duplicate value found",
+			},
+		},
+	})
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{
+			name:   "Has Code",
+			target: ErrDuplicateValue,
+			want:   true,
+		},
+		{
+			name:   "Does Not Have Code",
+			target: ErrInvalidSessionID,
+			want:   false,
+		},
+		{
+			name:   "Not A Code",
+			target: errors.New("DUPLICATE_VALUE"),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		t.Fatal("errors.As() did not find the API error")
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("errors.As() status code = %d, want %d", apiErr.StatusCode, http.StatusBadRequest)
+	}
+}
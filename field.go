@@ -0,0 +1,191 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrFieldAbsent is returned by the typed accessors when the field is not
+	// present in the record.
+	ErrFieldAbsent = errors.New("record: field is not present")
+	// ErrFieldNull is returned by the typed accessors when the field is null.
+	ErrFieldNull = errors.New("record: field is null")
+)
+
+// FieldTypeError is returned by the typed accessors when the field's value
+// can not be converted to the type.
+//
+// Field is the field path.
+//
+// Type is the requested type.
+//
+// Value is the field's value.
+type FieldTypeError struct {
+	Field string
+	Type  string
+	Value interface{}
+}
+
+func (e *FieldTypeError) Error() string {
+	return fmt.Sprintf("record: field %s is %T, not %s", e.Field, e.Value, e.Type)
+}
+
+// IsNull returns true if the field is null.  The field can be a dotted path
+// through the look ups, like Account.Owner.Name.
+func (r *Record) IsNull(field string) bool {
+	_, _, err := r.pathValue(field)
+	return errors.Is(err, ErrFieldNull)
+}
+
+// StringValue returns the field's string value.  The field can be a dotted
+// path through the look ups, like Account.Owner.Name.
+func (r *Record) StringValue(field string) (string, error) {
+	_, value, err := r.pathValue(field)
+	if err != nil {
+		return "", err
+	}
+	str, ok := value.(string)
+	if ok == false {
+		return "", &FieldTypeError{Field: field, Type: "string", Value: value}
+	}
+	return str, nil
+}
+
+// Int64Value returns the field's integer value.  A number with a fraction is
+// a type error.
+func (r *Record) Int64Value(field string) (int64, error) {
+	number, err := r.NumberValue(field)
+	if err != nil {
+		return 0, err
+	}
+	if value, err := number.Int64(); err == nil {
+		return value, nil
+	}
+	value, err := number.Float64()
+	if err != nil || value != math.Trunc(value) || value > math.MaxInt64 || value < math.MinInt64 {
+		return 0, &FieldTypeError{Field: field, Type: "int64", Value: number}
+	}
+	return int64(value), nil
+}
+
+// Float64Value returns the field's floating point value.
+func (r *Record) Float64Value(field string) (float64, error) {
+	_, value, err := r.pathValue(field)
+	if err != nil {
+		return 0, err
+	}
+	number, ok := value.(float64)
+	if ok == false {
+		return 0, &FieldTypeError{Field: field, Type: "float64", Value: value}
+	}
+	return number, nil
+}
+
+// NumberValue returns the field's number as it was in the JSON response, so
+// that currency and decimal values do not lose precision.
+func (r *Record) NumberValue(field string) (json.Number, error) {
+	rec, value, err := r.pathValue(field)
+	if err != nil {
+		return "", err
+	}
+	if number, has := rec.numbers[field[strings.LastIndex(field, ".")+1:]]; has {
+		return number, nil
+	}
+	number, ok := value.(float64)
+	if ok == false {
+		return "", &FieldTypeError{Field: field, Type: "number", Value: value}
+	}
+	return json.Number(strconv.FormatFloat(number, 'f', -1, 64)), nil
+}
+
+// BoolValue returns the field's boolean value.
+func (r *Record) BoolValue(field string) (bool, error) {
+	_, value, err := r.pathValue(field)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if ok == false {
+		return false, &FieldTypeError{Field: field, Type: "bool", Value: value}
+	}
+	return b, nil
+}
+
+// TimeValue returns the field's date time value, parsed with ParseTime.
+func (r *Record) TimeValue(field string) (time.Time, error) {
+	str, err := r.StringValue(field)
+	if err != nil {
+		var typeErr *FieldTypeError
+		if errors.As(err, &typeErr) {
+			typeErr.Type = "time"
+		}
+		return time.Time{}, err
+	}
+	value, err := ParseTime(str)
+	if err != nil {
+		return time.Time{}, &FieldTypeError{Field: field, Type: "time", Value: str}
+	}
+	return value, nil
+}
+
+// DateValue returns the field's date value.  The time of day is removed from
+// date time values.
+func (r *Record) DateValue(field string) (time.Time, error) {
+	value, err := r.TimeValue(field)
+	if err != nil {
+		var typeErr *FieldTypeError
+		if errors.As(err, &typeErr) {
+			typeErr.Type = "date"
+		}
+		return time.Time{}, err
+	}
+	year, month, day := value.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// MultiPicklistValue returns the selected values of the multi-select picklist field.
+func (r *Record) MultiPicklistValue(field string) ([]string, error) {
+	str, err := r.StringValue(field)
+	if err != nil {
+		var typeErr *FieldTypeError
+		if errors.As(err, &typeErr) {
+			typeErr.Type = "multi-select picklist"
+		}
+		return nil, err
+	}
+	if str == "" {
+		return []string{}, nil
+	}
+	return strings.Split(str, ";"), nil
+}
+
+// pathValue follows the dotted path through the look ups and returns the
+// record that has the field and the field's value.
+func (r *Record) pathValue(path string) (*Record, interface{}, error) {
+	parts := strings.Split(path, ".")
+	rec := r
+	for idx, part := range parts {
+		if _, null := rec.nulls[part]; null {
+			return nil, nil, fmt.Errorf("%w: %s", ErrFieldNull, path)
+		}
+		if idx == len(parts)-1 {
+			break
+		}
+		lookUp, has := rec.lookUps[part]
+		if has == false {
+			return nil, nil, fmt.Errorf("%w: %s", ErrFieldAbsent, path)
+		}
+		rec = lookUp
+	}
+	value, has := rec.fields[parts[len(parts)-1]]
+	if has == false {
+		return nil, nil, fmt.Errorf("%w: %s", ErrFieldAbsent, path)
+	}
+	return rec, value, nil
+}
@@ -0,0 +1,245 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testFieldRecord(t *testing.T) *Record {
+	var record Record
+	err := json.Unmarshal([]byte(`
+	{
+		"attributes": {
+			"type": "Contact",
+			"url": "/services/data/v45.0/sobjects/Contact/0032E00002CUjCgQAL"
+		},
+		"Name": "Jane Smith",
+		"Amount__c": 12345678901234567.89,
+		"NumberOfChildren__c": 3,
+		"Whole__c": 4.0,
+		"Rating__c": 4.5,
+		"Active__c": true,
+		"Birthdate": "1980-02-29",
+		"LastModifiedDate": "2019-03-26T15:30:45.000+0000",
+		"Interests__c": "Golf;Sailing;Chess",
+		"Empty__c": "",
+		"Fax": null,
+		"ReportsTo": null,
+		"Account": {
+			"attributes": {
+				"type": "Account",
+				"url": "/services/data/v45.0/sobjects/Account/0012E00001q0KijQAE"
+			},
+			"Name": "Acme",
+			"Owner": {
+				"attributes": {
+					"type": "User",
+					"url": "/services/data/v45.0/sobjects/User/0052E00000Hk7mbQAB"
+				},
+				"Name": "John Doe",
+				"Manager": null
+			}
+		}
+	}`), &record)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return &record
+}
+
+func TestRecord_StringValue(t *testing.T) {
+	record := testFieldRecord(t)
+	tests := []struct {
+		name     string
+		field    string
+		want     string
+		wantErr  error
+		wantType bool
+	}{
+		{
+			name:  "Passing",
+			field: "Name",
+			want:  "Jane Smith",
+		},
+		{
+			name:  "Look Up",
+			field: "Account.Owner.Name",
+			want:  "John Doe",
+		},
+		{
+			name:    "Absent",
+			field:   "Title",
+			wantErr: ErrFieldAbsent,
+		},
+		{
+			name:    "Absent Look Up",
+			field:   "Owner.Name",
+			wantErr: ErrFieldAbsent,
+		},
+		{
+			name:    "Null",
+			field:   "Fax",
+			wantErr: ErrFieldNull,
+		},
+		{
+			name:    "Null Look Up",
+			field:   "ReportsTo.Name",
+			wantErr: ErrFieldNull,
+		},
+		{
+			name:    "Null Nested Look Up",
+			field:   "Account.Owner.Manager.Name",
+			wantErr: ErrFieldNull,
+		},
+		{
+			name:     "Wrong Type",
+			field:    "Active__c",
+			wantType: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := record.StringValue(tt.field)
+			var typeErr *FieldTypeError
+			switch {
+			case tt.wantErr != nil && errors.Is(err, tt.wantErr) == false:
+				t.Fatalf("Record.StringValue() error = %v, want %v", err, tt.wantErr)
+			case tt.wantType && errors.As(err, &typeErr) == false:
+				t.Fatalf("Record.StringValue() error = %v, want a type error", err)
+			case tt.wantErr == nil && tt.wantType == false && err != nil:
+				t.Fatalf("Record.StringValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Record.StringValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecord_NumberValues(t *testing.T) {
+	record := testFieldRecord(t)
+
+	if got, err := record.Int64Value("NumberOfChildren__c"); err != nil || got != 3 {
+		t.Errorf("Record.Int64Value() = %v, %v, want 3", got, err)
+	}
+	if got, err := record.Int64Value("Whole__c"); err != nil || got != 4 {
+		t.Errorf("Record.Int64Value() = %v, %v, want 4", got, err)
+	}
+	var typeErr *FieldTypeError
+	if _, err := record.Int64Value("Rating__c"); errors.As(err, &typeErr) == false {
+		t.Errorf("Record.Int64Value() error = %v, want a type error", err)
+	}
+	if got, err := record.Float64Value("Rating__c"); err != nil || got != 4.5 {
+		t.Errorf("Record.Float64Value() = %v, %v, want 4.5", got, err)
+	}
+	if _, err := record.Float64Value("Name"); errors.As(err, &typeErr) == false {
+		t.Errorf("Record.Float64Value() error = %v, want a type error", err)
+	}
+	if got, err := record.NumberValue("Amount__c"); err != nil || got != "12345678901234567.89" {
+		t.Errorf("Record.NumberValue() = %v, %v, want 12345678901234567.89", got, err)
+	}
+	if got, _ := record.FieldValue("Amount__c"); reflect.TypeOf(got).Kind() != reflect.Float64 {
+		t.Errorf("Record.FieldValue() = %T, want float64", got)
+	}
+
+	fromMap, err := RecordFromJSONMap(map[string]interface{}{"Rating__c": 4.25})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if got, err := fromMap.NumberValue("Rating__c"); err != nil || got != "4.25" {
+		t.Errorf("Record.NumberValue() = %v, %v, want 4.25", got, err)
+	}
+}
+
+func TestRecord_BoolValue(t *testing.T) {
+	record := testFieldRecord(t)
+
+	if got, err := record.BoolValue("Active__c"); err != nil || got != true {
+		t.Errorf("Record.BoolValue() = %v, %v, want true", got, err)
+	}
+	if _, err := record.BoolValue("Fax"); errors.Is(err, ErrFieldNull) == false {
+		t.Errorf("Record.BoolValue() error = %v, want %v", err, ErrFieldNull)
+	}
+}
+
+func TestRecord_TimeValues(t *testing.T) {
+	record := testFieldRecord(t)
+
+	want := time.Date(2019, time.March, 26, 15, 30, 45, 0, time.UTC)
+	if got, err := record.TimeValue("LastModifiedDate"); err != nil || got.Equal(want) == false {
+		t.Errorf("Record.TimeValue() = %v, %v, want %v", got, err, want)
+	}
+	want = time.Date(2019, time.March, 26, 0, 0, 0, 0, time.UTC)
+	if got, err := record.DateValue("LastModifiedDate"); err != nil || got.Equal(want) == false {
+		t.Errorf("Record.DateValue() = %v, %v, want %v", got, err, want)
+	}
+	want = time.Date(1980, time.February, 29, 0, 0, 0, 0, time.UTC)
+	if got, err := record.DateValue("Birthdate"); err != nil || got.Equal(want) == false {
+		t.Errorf("Record.DateValue() = %v, %v, want %v", got, err, want)
+	}
+
+	var typeErr *FieldTypeError
+	if _, err := record.TimeValue("Name"); errors.As(err, &typeErr) == false || typeErr.Type != "time" {
+		t.Errorf("Record.TimeValue() error = %v, want a time type error", err)
+	}
+	if _, err := record.DateValue("Active__c"); errors.As(err, &typeErr) == false || typeErr.Type != "date" {
+		t.Errorf("Record.DateValue() error = %v, want a date type error", err)
+	}
+}
+
+func TestRecord_MultiPicklistValue(t *testing.T) {
+	record := testFieldRecord(t)
+
+	tests := []struct {
+		name    string
+		field   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Passing",
+			field:   "Interests__c",
+			want:    []string{"Golf", "Sailing", "Chess"},
+			wantErr: false,
+		},
+		{
+			name:    "Empty",
+			field:   "Empty__c",
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "Null",
+			field:   "Fax",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := record.MultiPicklistValue(tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Record.MultiPicklistValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Record.MultiPicklistValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecord_IsNull(t *testing.T) {
+	record := testFieldRecord(t)
+
+	if record.IsNull("Fax") == false {
+		t.Error("Record.IsNull() Fax = false, want true")
+	}
+	if record.IsNull("Account.Owner.Manager") == false {
+		t.Error("Record.IsNull() Account.Owner.Manager = false, want true")
+	}
+	if record.IsNull("Name") || record.IsNull("Title") {
+		t.Error("Record.IsNull() = true for a present or absent field")
+	}
+}
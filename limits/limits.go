@@ -0,0 +1,78 @@
+// Package limits provides the Salesforce org limits API.
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/session"
+)
+
+const endpoint = "/limits"
+
+// Resource is the structure for the Salesforce limits API.
+type Resource struct {
+	session session.ServiceFormatter
+}
+
+// NewResource creates a new limits resource with the session.  If the
+// session is nil an error will be returned.
+func NewResource(session session.ServiceFormatter) (*Resource, error) {
+	if session == nil {
+		return nil, errors.New("limits: session can not be nil")
+	}
+	return &Resource{
+		session: session,
+	}, nil
+}
+
+// Retrieve will return the org's limits.
+func (r *Resource) Retrieve() (Value, error) {
+	return r.RetrieveWithContext(context.Background())
+}
+
+// RetrieveWithContext will return the org's limits using the context for the request.
+func (r *Resource) RetrieveWithContext(ctx context.Context) (Value, error) {
+	request, err := r.request(ctx)
+	if err != nil {
+		return Value{}, err
+	}
+	return r.response(request)
+}
+
+func (r *Resource) request(ctx context.Context) (*http.Request, error) {
+	url := r.session.ServiceURL() + endpoint
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Accept", "application/json")
+	r.session.AuthorizationHeader(request)
+	return request, nil
+}
+
+func (r *Resource) response(request *http.Request) (Value, error) {
+	response, err := r.session.Client().Do(request)
+	if err != nil {
+		return Value{}, err
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Value{}, sfdc.NewAPIError(response)
+	}
+
+	var value Value
+	err = decoder.Decode(&value)
+	if err != nil {
+		return Value{}, err
+	}
+	return value, nil
+}
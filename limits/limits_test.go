@@ -0,0 +1,208 @@
+package limits
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc/session"
+)
+
+func TestNewResource(t *testing.T) {
+	type args struct {
+		session session.ServiceFormatter
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Resource
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			args: args{
+				session: &mockSessionFormatter{},
+			},
+			want: &Resource{
+				session: &mockSessionFormatter{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No Session",
+			args:    args{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResource(tt.args.session)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewResource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_Retrieve(t *testing.T) {
+	type fields struct {
+		session session.ServiceFormatter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    Value
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com/services/data/v45.0",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/data/v45.0/limits" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						resp := `
+						{
+							"DailyApiRequests" : {
+								"Max" : 15000,
+								"Remaining" : 14998,
+								"Ant Migration Tool" : {
+									"Max" : 0,
+									"Remaining" : 0
+								},
+								"Salesforce CLI" : {
+									"Max" : 0,
+									"Remaining" : 0
+								}
+							},
+							"DailyBulkV2QueryJobs" : {
+								"Max" : 10000,
+								"Remaining" : 9990
+							},
+							"DataStorageMB" : {
+								"Max" : 5,
+								"Remaining" : 5
+							}
+						}`
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Some Status",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			want: Value{
+				DailyApiRequests: Limit{
+					Max:       15000,
+					Remaining: 14998,
+					Apps: map[string]AppLimit{
+						"Ant Migration Tool": {},
+						"Salesforce CLI":     {},
+					},
+				},
+				DailyBulkV2QueryJobs: Limit{
+					Max:       10000,
+					Remaining: 9990,
+				},
+				DataStorageMB: Limit{
+					Max:       5,
+					Remaining: 5,
+				},
+				All: map[string]Limit{
+					"DailyApiRequests": {
+						Max:       15000,
+						Remaining: 14998,
+						Apps: map[string]AppLimit{
+							"Ant Migration Tool": {},
+							"Salesforce CLI":     {},
+						},
+					},
+					"DailyBulkV2QueryJobs": {
+						Max:       10000,
+						Remaining: 9990,
+					},
+					"DataStorageMB": {
+						Max:       5,
+						Remaining: 5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Response Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com/services/data/v45.0",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `
+						[
+							{
+								"message" : "Session expired or invalid",
+								"errorCode" : "INVALID_SESSION_ID"
+							}
+						]`
+						return &http.Response{
+							StatusCode: http.StatusUnauthorized,
+							Status:     "Unauthorized",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			want:    Value{},
+			wantErr: true,
+		},
+		{
+			name: "Response JSON Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com/services/data/v45.0",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Some Status",
+							Body:       ioutil.NopCloser(strings.NewReader(`{`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			want:    Value{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: tt.fields.session,
+			}
+			got, err := r.RetrieveWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.Retrieve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resource.Retrieve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package limits
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// AppLimit is the usage of a limit by a connected application.
+type AppLimit struct {
+	Max       int `json:"Max"`
+	Remaining int `json:"Remaining"`
+}
+
+// Limit is an org limit.
+//
+// Max is the limit for the org.
+//
+// Remaining is the amount of the limit that is left.
+//
+// Apps is the usage of the limit by each connected application, if
+// Salesforce returns it for the limit.
+type Limit struct {
+	Max       int
+	Remaining int
+	Apps      map[string]AppLimit
+}
+
+// Value is the org's limits.  The common limits are fields of the value, and
+// All has every limit returned by Salesforce, keyed by the limit's name.
+type Value struct {
+	ConcurrentAsyncGetReportInstances     Limit            `json:"ConcurrentAsyncGetReportInstances"`
+	ConcurrentSyncReportRuns              Limit            `json:"ConcurrentSyncReportRuns"`
+	DailyAnalyticsDataflowJobExecutions   Limit            `json:"DailyAnalyticsDataflowJobExecutions"`
+	DailyApiRequests                      Limit            `json:"DailyApiRequests"`
+	DailyAsyncApexExecutions              Limit            `json:"DailyAsyncApexExecutions"`
+	DailyBulkApiBatches                   Limit            `json:"DailyBulkApiBatches"`
+	DailyBulkV2QueryFileStorageMB         Limit            `json:"DailyBulkV2QueryFileStorageMB"`
+	DailyBulkV2QueryJobs                  Limit            `json:"DailyBulkV2QueryJobs"`
+	DailyDurableGenericStreamingApiEvents Limit            `json:"DailyDurableGenericStreamingApiEvents"`
+	DailyDurableStreamingApiEvents        Limit            `json:"DailyDurableStreamingApiEvents"`
+	DailyGenericStreamingApiEvents        Limit            `json:"DailyGenericStreamingApiEvents"`
+	DailyStandardVolumePlatformEvents     Limit            `json:"DailyStandardVolumePlatformEvents"`
+	DailyStreamingApiEvents               Limit            `json:"DailyStreamingApiEvents"`
+	DailyWorkflowEmails                   Limit            `json:"DailyWorkflowEmails"`
+	DataStorageMB                         Limit            `json:"DataStorageMB"`
+	FileStorageMB                         Limit            `json:"FileStorageMB"`
+	HourlyAsyncReportRuns                 Limit            `json:"HourlyAsyncReportRuns"`
+	HourlyDashboardRefreshes              Limit            `json:"HourlyDashboardRefreshes"`
+	HourlyODataCallout                    Limit            `json:"HourlyODataCallout"`
+	HourlySyncReportRuns                  Limit            `json:"HourlySyncReportRuns"`
+	HourlyTimeBasedWorkflow               Limit            `json:"HourlyTimeBasedWorkflow"`
+	MassEmail                             Limit            `json:"MassEmail"`
+	SingleEmail                           Limit            `json:"SingleEmail"`
+	All                                   map[string]Limit `json:"-"`
+}
+
+// Used returns the amount of the limit that has been used.
+func (l Limit) Used() int {
+	return l.Max - l.Remaining
+}
+
+// Percent returns the percentage of the limit that has been used.
+func (l Limit) Percent() float64 {
+	if l.Max <= 0 {
+		return 0
+	}
+	return float64(l.Used()) / float64(l.Max) * 100
+}
+
+// UnmarshalJSON will unmarshal the limit and the usage by each connected
+// application.
+func (l *Limit) UnmarshalJSON(data []byte) error {
+	if l == nil {
+		return errors.New("limit: can't unmarshal to a nil struct")
+	}
+
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
+	}
+
+	for key, raw := range jsonMap {
+		switch key {
+		case "Max":
+			if err := json.Unmarshal(raw, &l.Max); err != nil {
+				return err
+			}
+		case "Remaining":
+			if err := json.Unmarshal(raw, &l.Remaining); err != nil {
+				return err
+			}
+		default:
+			var app AppLimit
+			if err := json.Unmarshal(raw, &app); err != nil {
+				continue
+			}
+			if l.Apps == nil {
+				l.Apps = make(map[string]AppLimit)
+			}
+			l.Apps[key] = app
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON will unmarshal the common limits and every limit into All.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	if v == nil {
+		return errors.New("limits: can't unmarshal to a nil struct")
+	}
+
+	type value Value
+	var typed value
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	all := make(map[string]Limit)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	*v = Value(typed)
+	v.All = all
+	return nil
+}
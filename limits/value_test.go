@@ -0,0 +1,99 @@
+package limits
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLimit_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Limit
+		wantErr bool
+	}{
+		{
+			name: "Limit",
+			data: `{"Max":100,"Remaining":40}`,
+			want: Limit{
+				Max:       100,
+				Remaining: 40,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Apps",
+			data: `{"Max":100,"Remaining":40,"My App":{"Max":10,"Remaining":5}}`,
+			want: Limit{
+				Max:       100,
+				Remaining: 40,
+				Apps: map[string]AppLimit{
+					"My App": {
+						Max:       10,
+						Remaining: 5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Max Not A Number",
+			data:    `{"Max":"lots","Remaining":40}`,
+			wantErr: true,
+		},
+		{
+			name:    "Not An Object",
+			data:    `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Limit
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Limit.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Limit.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimit_Percent(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Limit
+		used  int
+		want  float64
+	}{
+		{
+			name: "Used",
+			limit: Limit{
+				Max:       15000,
+				Remaining: 3000,
+			},
+			used: 12000,
+			want: 80,
+		},
+		{
+			name:  "No Max",
+			limit: Limit{},
+			used:  0,
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.Used(); got != tt.used {
+				t.Errorf("Limit.Used() = %d, want %d", got, tt.used)
+			}
+			if got := tt.limit.Percent(); got != tt.want {
+				t.Errorf("Limit.Percent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
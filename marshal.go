@@ -0,0 +1,536 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructTag is the struct field tag key used to map a struct field to a
+// Salesforce field.
+//
+// The first tag value is the field name, which defaults to the Go field name.  A
+// dotted name, like Account.Name, is read through the record's look ups.  A name
+// of "-" skips the field.  The options follow the name:
+//
+// omitempty will not send the field when it has its zero value.
+//
+// readonly will not send the field, but it will still be decoded.
+//
+// null will send the zero value as an explicit null, which clears the field.
+// Without it, a nil pointer field is not sent.
+//
+// externalid marks the field as the external ID used for upserts.
+//
+// date formats a time.Time field as a Salesforce date.
+const StructTag = "sfdc"
+
+const (
+	tagOmitEmpty  = "omitempty"
+	tagReadOnly   = "readonly"
+	tagNull       = "null"
+	tagExternalID = "externalid"
+	tagDate       = "date"
+	idField       = "Id"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	numberType = reflect.TypeOf(json.Number(""))
+)
+
+type structField struct {
+	index      int
+	name       string
+	omitEmpty  bool
+	readOnly   bool
+	null       bool
+	externalID bool
+	date       bool
+}
+
+// Unmarshal decodes the record into the struct pointed to by v.  Look up
+// records are decoded into nested struct fields.  Fields that are absent from the
+// record are left unchanged and null fields are set to their zero value.
+func Unmarshal(record *Record, v interface{}) error {
+	return UnmarshalChildren(record, nil, v)
+}
+
+// UnmarshalChildren decodes the record into the struct pointed to by v, like
+// Unmarshal, and decodes the child records into the struct slice fields.  The
+// children are keyed by the relationship name, like Contacts.
+func UnmarshalChildren(record *Record, children map[string][]*Record, v interface{}) error {
+	if record == nil {
+		return errors.New("unmarshal: record can not be nil")
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal: %T is not a pointer to a struct", v)
+	}
+	return unmarshalStruct(record, children, value.Elem())
+}
+
+func unmarshalStruct(record *Record, children map[string][]*Record, value reflect.Value) error {
+	for _, field := range structFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+		switch {
+		case isStruct(fieldValue.Type()):
+			if err := unmarshalLookUp(record, field.name, fieldValue); err != nil {
+				return err
+			}
+		case isStructSlice(fieldValue.Type()):
+			records, has := children[field.name]
+			if has == false {
+				continue
+			}
+			if err := unmarshalChildren(records, fieldValue); err != nil {
+				return err
+			}
+		default:
+			if err := unmarshalField(record, field, fieldValue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unmarshalLookUp(record *Record, name string, value reflect.Value) error {
+	lookUp := record
+	for _, part := range strings.Split(name, ".") {
+		if _, null := lookUp.nulls[part]; null {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		var has bool
+		if lookUp, has = lookUp.LookUp(part); has == false {
+			return nil
+		}
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	return unmarshalStruct(lookUp, nil, value)
+}
+
+func unmarshalChildren(records []*Record, value reflect.Value) error {
+	slice := reflect.MakeSlice(value.Type(), len(records), len(records))
+	for idx, record := range records {
+		elem := slice.Index(idx)
+		if elem.Kind() == reflect.Ptr {
+			elem.Set(reflect.New(elem.Type().Elem()))
+			elem = elem.Elem()
+		}
+		if err := unmarshalStruct(record, nil, elem); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+	return nil
+}
+
+func unmarshalField(record *Record, field structField, value reflect.Value) error {
+	_, raw, err := record.pathValue(field.name)
+	switch {
+	case errors.Is(err, ErrFieldAbsent):
+		return nil
+	case errors.Is(err, ErrFieldNull):
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	case err != nil:
+		return err
+	}
+	if value.Kind() == reflect.Ptr {
+		elem := reflect.New(value.Type().Elem())
+		if err := setField(record, field, raw, elem.Elem()); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	}
+	return setField(record, field, raw, value)
+}
+
+func setField(record *Record, field structField, raw interface{}, value reflect.Value) error {
+	switch value.Type() {
+	case timeType:
+		var date time.Time
+		var err error
+		if field.date {
+			date, err = record.DateValue(field.name)
+		} else {
+			date, err = record.TimeValue(field.name)
+		}
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(date))
+		return nil
+	case numberType:
+		number, err := record.NumberValue(field.name)
+		if err != nil {
+			return err
+		}
+		value.SetString(string(number))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		str, err := record.StringValue(field.name)
+		if err != nil {
+			return err
+		}
+		value.SetString(str)
+	case reflect.Bool:
+		b, err := record.BoolValue(field.name)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := record.Int64Value(field.name)
+		if err != nil {
+			return err
+		}
+		if value.OverflowInt(i) {
+			return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := record.Int64Value(field.name)
+		if err != nil {
+			return err
+		}
+		if i < 0 || value.OverflowUint(uint64(i)) {
+			return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+		}
+		value.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, err := record.Float64Value(field.name)
+		if err != nil {
+			return err
+		}
+		if value.OverflowFloat(f) {
+			return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+		}
+		values, err := record.MultiPicklistValue(field.name)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(value.Type(), len(values), len(values))
+		for idx, v := range values {
+			slice.Index(idx).SetString(v)
+		}
+		value.Set(slice)
+	case reflect.Interface:
+		if raw != nil && reflect.TypeOf(raw).AssignableTo(value.Type()) == false {
+			return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+		}
+		value.Set(reflect.ValueOf(raw))
+	default:
+		return &FieldTypeError{Field: field.name, Type: value.Type().String(), Value: raw}
+	}
+	return nil
+}
+
+// Marshal encodes the struct, or pointer to a struct, into the record fields
+// that are sent to Salesforce.  Read only fields, look up structs, child slices
+// and dotted names are not part of the fields.  A time.Time is formatted as a
+// Salesforce date time and a string slice is joined as a multi-select picklist.
+// Only an empty field tagged with null is an explicit nil, and a nil pointer
+// field without the tag is not sent.  If a field's type is not supported, an
+// error is returned, even when the field is empty.
+func Marshal(v interface{}) (map[string]interface{}, error) {
+	value, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMarshal(value.Type()); err != nil {
+		return nil, err
+	}
+	return marshalStruct(value), nil
+}
+
+// marshalStruct encodes the struct fields.  The field types must have been
+// checked with checkMarshal.
+func marshalStruct(value reflect.Value) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for _, field := range structFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+		if marshaled(field, fieldValue.Type()) == false {
+			continue
+		}
+		if isEmpty(fieldValue) {
+			switch {
+			case field.null:
+				fields[field.name] = nil
+				continue
+			case field.omitEmpty, fieldValue.Kind() == reflect.Ptr:
+				continue
+			}
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			fieldValue = fieldValue.Elem()
+		}
+		fields[field.name] = marshalField(field, fieldValue)
+	}
+	return fields
+}
+
+// marshaled returns true if the field is sent to Salesforce.
+func marshaled(field structField, fieldType reflect.Type) bool {
+	return field.readOnly == false && strings.Contains(field.name, ".") == false && isStruct(fieldType) == false && isStructSlice(fieldType) == false
+}
+
+// checkMarshal checks that the type of each sent field is able to be
+// marshaled, so that the check does not depend on the field values.
+func checkMarshal(structType reflect.Type) error {
+	for _, field := range structFields(structType) {
+		fieldType := structType.Field(field.index).Type
+		if marshaled(field, fieldType) == false {
+			continue
+		}
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType {
+		case timeType, numberType:
+			continue
+		}
+		switch fieldType.Kind() {
+		case reflect.String, reflect.Bool, reflect.Interface,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			continue
+		case reflect.Slice:
+			if fieldType.Elem().Kind() == reflect.String {
+				continue
+			}
+		}
+		return fmt.Errorf("marshal: field %s of type %s is not supported", field.name, fieldType)
+	}
+	return nil
+}
+
+// marshalField encodes the field value.  The type must have been checked with
+// checkMarshal, so an interface is the only other value.
+func marshalField(field structField, value reflect.Value) interface{} {
+	switch value.Type() {
+	case timeType:
+		date := value.Interface().(time.Time).UTC()
+		if field.date {
+			return date.Format(SalesforceDate)
+		}
+		return date.Format(SalesforceDateTime)
+	case numberType:
+		if value.String() == "" {
+			return json.Number("0")
+		}
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint()
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	case reflect.Slice:
+		values := make([]string, value.Len())
+		for idx := range values {
+			values[idx] = value.Index(idx).String()
+		}
+		return strings.Join(values, ";")
+	}
+	return value.Interface()
+}
+
+// StructRecord uses a tagged struct as the record to insert, update, upsert or
+// delete.  It can be used as a bulk record as well.
+type StructRecord struct {
+	sobject string
+	value   reflect.Value
+	upsert  bool
+}
+
+// NewStructRecord creates a record from the struct, or pointer to a struct.  The
+// ID is the struct field named Id.  When v is a pointer, changes to the struct
+// are reflected in the record's fields.  If a field's type is not able to be
+// marshaled, an error is returned.
+func NewStructRecord(sobject string, v interface{}) (*StructRecord, error) {
+	if sobject == "" {
+		return nil, errors.New("struct record: sobject can not be empty")
+	}
+	value, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMarshal(value.Type()); err != nil {
+		return nil, err
+	}
+	return &StructRecord{
+		sobject: sobject,
+		value:   value,
+	}, nil
+}
+
+// NewStructUpsertRecord creates a record from the struct, or pointer to a struct,
+// to upsert.  The ID is the value of the struct field tagged with externalid.
+func NewStructUpsertRecord(sobject string, v interface{}) (*StructRecord, error) {
+	record, err := NewStructRecord(sobject, v)
+	if err != nil {
+		return nil, err
+	}
+	if record.ExternalField() == "" {
+		return nil, fmt.Errorf("struct record: %s does not have an externalid field", record.value.Type())
+	}
+	record.upsert = true
+	return record, nil
+}
+
+// SObject returns the Salesforce object name.
+func (r *StructRecord) SObject() string {
+	return r.sobject
+}
+
+// Fields returns the marshaled struct fields.  The field types were checked
+// when the record was created, so the struct is always able to be marshaled.
+func (r *StructRecord) Fields() map[string]interface{} {
+	return marshalStruct(r.value)
+}
+
+// ID returns the Salesforce ID, or the external ID for an upsert record.
+func (r *StructRecord) ID() string {
+	for _, field := range structFields(r.value.Type()) {
+		if (r.upsert && field.externalID) || (r.upsert == false && strings.EqualFold(field.name, idField)) {
+			value := r.value.Field(field.index)
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					return ""
+				}
+				value = value.Elem()
+			}
+			return fmt.Sprintf("%v", value.Interface())
+		}
+	}
+	return ""
+}
+
+// ExternalField returns the name of the field tagged with externalid.
+func (r *StructRecord) ExternalField() string {
+	for _, field := range structFields(r.value.Type()) {
+		if field.externalID {
+			return field.name
+		}
+	}
+	return ""
+}
+
+// InsertNull returns true when one of the fields is an explicit null.
+func (r *StructRecord) InsertNull() bool {
+	for _, value := range r.Fields() {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ExplicitNull returns true, so that the bulk formatter only nulls the fields
+// that are an explicit null, and not the fields that are absent.
+func (r *StructRecord) ExplicitNull() bool {
+	return true
+}
+
+func structValue(v interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr && value.IsNil() == false {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("marshal: %T is not a struct", v)
+	}
+	if value.CanAddr() == false {
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		value = copied
+	}
+	return value, nil
+}
+
+func structFields(structType reflect.Type) []structField {
+	var fields []structField
+	for idx := 0; idx < structType.NumField(); idx++ {
+		sf := structType.Field(idx)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get(StructTag)
+		if tag == "-" {
+			continue
+		}
+		options := strings.Split(tag, ",")
+		field := structField{
+			index: idx,
+			name:  options[0],
+		}
+		if field.name == "" {
+			field.name = sf.Name
+		}
+		for _, option := range options[1:] {
+			switch option {
+			case tagOmitEmpty:
+				field.omitEmpty = true
+			case tagReadOnly:
+				field.readOnly = true
+			case tagNull:
+				field.null = true
+			case tagExternalID:
+				field.externalID = true
+			case tagDate:
+				field.date = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func isStruct(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind() == reflect.Struct && fieldType != timeType
+}
+
+func isStructSlice(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.Slice && isStruct(fieldType.Elem())
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
@@ -0,0 +1,332 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testOwner struct {
+	Name    string
+	Manager *testOwner
+}
+
+type testAccount struct {
+	Name  string
+	Owner *testOwner
+}
+
+type testContact struct {
+	ID               string      `sfdc:"Id,readonly"`
+	Name             string      `sfdc:"Name,readonly"`
+	Amount           json.Number `sfdc:"Amount__c"`
+	Children         int8        `sfdc:"NumberOfChildren__c"`
+	Rating           float64     `sfdc:"Rating__c"`
+	Active           bool        `sfdc:"Active__c"`
+	Birthdate        time.Time   `sfdc:"Birthdate,date"`
+	LastModifiedDate time.Time   `sfdc:",readonly"`
+	Interests        []string    `sfdc:"Interests__c,omitempty"`
+	Fax              *string     `sfdc:",null"`
+	Phone            string      `sfdc:",omitempty"`
+	Title            string      `sfdc:",null"`
+	AccountName      string      `sfdc:"Account.Name"`
+	OwnerName        string      `sfdc:"Account.Owner.Name"`
+	Account          testAccount
+	ReportsTo        *testOwner
+	Notes            []testOwner
+	Ignored          string `sfdc:"-"`
+	private          string
+}
+
+func TestUnmarshal(t *testing.T) {
+	record := testFieldRecord(t)
+	fax := "555-1234"
+	got := testContact{
+		Fax:       &fax,
+		ReportsTo: &testOwner{Name: "Old"},
+		Ignored:   "Ignored",
+	}
+	if err := Unmarshal(record, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := testContact{
+		ID:               "",
+		Name:             "Jane Smith",
+		Amount:           json.Number("12345678901234567.89"),
+		Children:         3,
+		Rating:           4.5,
+		Active:           true,
+		Birthdate:        time.Date(1980, time.February, 29, 0, 0, 0, 0, time.UTC),
+		LastModifiedDate: time.Date(2019, time.March, 26, 15, 30, 45, 0, time.FixedZone("", 0)),
+		Interests:        []string{"Golf", "Sailing", "Chess"},
+		AccountName:      "Acme",
+		OwnerName:        "John Doe",
+		Account: testAccount{
+			Name: "Acme",
+			Owner: &testOwner{
+				Name: "John Doe",
+			},
+		},
+		Ignored: "Ignored",
+	}
+	if got.LastModifiedDate.Equal(want.LastModifiedDate) == false {
+		t.Errorf("Unmarshal() LastModifiedDate = %v, want %v", got.LastModifiedDate, want.LastModifiedDate)
+	}
+	got.LastModifiedDate = want.LastModifiedDate
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	record := testFieldRecord(t)
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "Not Pointer",
+			value: testContact{},
+		},
+		{
+			name:  "Nil Pointer",
+			value: (*testContact)(nil),
+		},
+		{
+			name:  "Not Struct",
+			value: new(string),
+		},
+		{
+			name: "Type Mismatch",
+			value: &struct {
+				Name bool
+			}{},
+		},
+		{
+			name: "Overflow",
+			value: &struct {
+				Amount int8 `sfdc:"Amount__c"`
+			}{},
+		},
+		{
+			name: "Negative Unsigned",
+			value: &struct {
+				Rating uint `sfdc:"Rating__c"`
+			}{},
+		},
+		{
+			name: "Unsupported",
+			value: &struct {
+				Name map[string]string
+			}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal(record, tt.value); err == nil {
+				t.Errorf("Unmarshal() expected an error")
+			}
+		})
+	}
+	if err := Unmarshal(nil, &testContact{}); err == nil {
+		t.Errorf("Unmarshal() expected an error for a nil record")
+	}
+}
+
+func TestUnmarshalChildren(t *testing.T) {
+	record := testFieldRecord(t)
+	child, err := RecordFromJSONMap(map[string]interface{}{
+		"Name": "Note 1",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var got testContact
+	err = UnmarshalChildren(record, map[string][]*Record{
+		"Notes": {child},
+	}, &got)
+	if err != nil {
+		t.Fatalf("UnmarshalChildren() error = %v", err)
+	}
+	if want := []testOwner{{Name: "Note 1"}}; !reflect.DeepEqual(got.Notes, want) {
+		t.Errorf("UnmarshalChildren() = %v, want %v", got.Notes, want)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	fax := "555-1234"
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "Zero",
+			value: testContact{
+				ID:   "0032E00002CUjCgQAL",
+				Name: "Jane Smith",
+			},
+			want: map[string]interface{}{
+				"Amount__c":           json.Number("0"),
+				"NumberOfChildren__c": int64(0),
+				"Rating__c":           float64(0),
+				"Active__c":           false,
+				"Birthdate":           "0001-01-01",
+				"Fax":                 nil,
+				"Title":               nil,
+			},
+		},
+		{
+			name: "Values",
+			value: &testContact{
+				Amount:    json.Number("12345678901234567.89"),
+				Children:  3,
+				Rating:    4.5,
+				Active:    true,
+				Birthdate: time.Date(1980, time.February, 29, 0, 0, 0, 0, time.UTC),
+				Interests: []string{"Golf", "Chess"},
+				Fax:       &fax,
+				Phone:     "555-9876",
+				Title:     "CEO",
+			},
+			want: map[string]interface{}{
+				"Amount__c":           json.Number("12345678901234567.89"),
+				"NumberOfChildren__c": int64(3),
+				"Rating__c":           4.5,
+				"Active__c":           true,
+				"Birthdate":           "1980-02-29",
+				"Interests__c":        "Golf;Chess",
+				"Fax":                 "555-1234",
+				"Phone":               "555-9876",
+				"Title":               "CEO",
+			},
+		},
+		{
+			name: "Date Time",
+			value: struct {
+				Start time.Time
+			}{
+				Start: time.Date(2019, time.March, 26, 10, 30, 45, 0, time.FixedZone("EST", -5*60*60)),
+			},
+			want: map[string]interface{}{
+				"Start": "2019-03-26T15:30:45.000+0000",
+			},
+		},
+		{
+			name:    "Not Struct",
+			value:   "Name",
+			wantErr: true,
+		},
+		{
+			name: "Unsupported",
+			value: struct {
+				Name map[string]string
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported Omit Empty",
+			value: struct {
+				Name map[string]string `sfdc:",omitempty"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Nil Pointers",
+			value: struct {
+				Name  string
+				Site  *string
+				Phone *string `sfdc:",null"`
+			}{
+				Name: "Acme",
+			},
+			want: map[string]interface{}{
+				"Name":  "Acme",
+				"Phone": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Marshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructRecord(t *testing.T) {
+	type account struct {
+		ID     string `sfdc:"Id,readonly"`
+		Name   string
+		Number int    `sfdc:"External__c,externalid"`
+		Site   string `sfdc:",null"`
+	}
+	value := &account{
+		ID:     "0012E00001q0KijQAE",
+		Name:   "Acme",
+		Number: 42,
+		Site:   "HQ",
+	}
+	record, err := NewStructRecord("Account", value)
+	if err != nil {
+		t.Fatalf("NewStructRecord() error = %v", err)
+	}
+	if record.SObject() != "Account" {
+		t.Errorf("StructRecord.SObject() = %v, want %v", record.SObject(), "Account")
+	}
+	if record.ID() != "0012E00001q0KijQAE" {
+		t.Errorf("StructRecord.ID() = %v, want %v", record.ID(), "0012E00001q0KijQAE")
+	}
+	if record.ExternalField() != "External__c" {
+		t.Errorf("StructRecord.ExternalField() = %v, want %v", record.ExternalField(), "External__c")
+	}
+	if record.InsertNull() {
+		t.Errorf("StructRecord.InsertNull() = %v, want %v", record.InsertNull(), false)
+	}
+
+	value.Site = ""
+	want := map[string]interface{}{
+		"Name":        "Acme",
+		"External__c": int64(42),
+		"Site":        nil,
+	}
+	if !reflect.DeepEqual(record.Fields(), want) {
+		t.Errorf("StructRecord.Fields() = %v, want %v", record.Fields(), want)
+	}
+	if record.InsertNull() == false {
+		t.Errorf("StructRecord.InsertNull() = %v, want %v", record.InsertNull(), true)
+	}
+
+	upsert, err := NewStructUpsertRecord("Account", value)
+	if err != nil {
+		t.Fatalf("NewStructUpsertRecord() error = %v", err)
+	}
+	if upsert.ID() != "42" {
+		t.Errorf("StructRecord.ID() = %v, want %v", upsert.ID(), "42")
+	}
+
+	if _, err := NewStructRecord("", value); err == nil {
+		t.Errorf("NewStructRecord() expected an error for an empty sobject")
+	}
+	if _, err := NewStructRecord("Account", []string{}); err == nil {
+		t.Errorf("NewStructRecord() expected an error for a slice")
+	}
+	unsupported := struct {
+		Name  string
+		Extra map[string]string `sfdc:",omitempty"`
+	}{}
+	if _, err := NewStructRecord("Account", &unsupported); err == nil {
+		t.Errorf("NewStructRecord() expected an error for an unsupported field type")
+	}
+	if _, err := NewStructUpsertRecord("Account", testOwner{}); err == nil {
+		t.Errorf("NewStructUpsertRecord() expected an error without an external field")
+	}
+}
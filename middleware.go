@@ -0,0 +1,66 @@
+package sfdc
+
+import (
+	"net/http"
+)
+
+// CallOptionsHeader is the Salesforce header for the API call options.
+const CallOptionsHeader = "Sforce-Call-Options"
+
+// Middleware wraps the round tripper of the HTTP pipeline.  The middleware is
+// able to change the request, inspect the response or record each call.  A
+// middleware that changes the request should change a clone of it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow a function to be used as a round
+// tripper.
+type RoundTripperFunc func(request *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Chain will wrap the round tripper with the middleware.  The first middleware
+// is the first one to receive the request.
+func Chain(transport http.RoundTripper, middleware ...Middleware) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for idx := len(middleware) - 1; idx >= 0; idx-- {
+		if middleware[idx] != nil {
+			transport = middleware[idx](transport)
+		}
+	}
+	return transport
+}
+
+// Header returns the middleware that will set the header on each request.
+func Header(key, value string) Middleware {
+	return HeaderFunc(key, func(*http.Request) string {
+		return value
+	})
+}
+
+// HeaderFunc returns the middleware that will set the header on each request
+// from the function, like a correlation ID from the request's context.  If the
+// function returns an empty string, the header is not set.
+func HeaderFunc(key string, value func(request *http.Request) string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			headerValue := value(request)
+			if headerValue == "" {
+				return next.RoundTrip(request)
+			}
+			clone := request.Clone(request.Context())
+			clone.Header.Set(key, headerValue)
+			return next.RoundTrip(clone)
+		})
+	}
+}
+
+// CallOptions returns the middleware that will set the Sforce-Call-Options
+// client name on each request.
+func CallOptions(client string) Middleware {
+	return Header(CallOptionsHeader, "client="+client)
+}
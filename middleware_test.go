@@ -0,0 +1,106 @@
+package sfdc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type correlationKey struct{}
+
+func mockRecordingTransport(headers *http.Header) http.RoundTripper {
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		*headers = request.Header
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}, nil
+	})
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	named := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(request)
+			})
+		}
+	}
+	var headers http.Header
+	transport := Chain(mockRecordingTransport(&headers), named("first"), nil, named("second"))
+
+	request, _ := http.NewRequest(http.MethodGet, "https://test.salesforce.com", nil)
+	if _, err := transport.RoundTrip(request); err != nil {
+		t.Fatalf("Chain() error = %v", err)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Chain() order = %v, want %v", order, want)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware Middleware
+		ctx        context.Context
+		key        string
+		want       string
+	}{
+		{
+			name:       "Call Options",
+			middleware: CallOptions("my-integration"),
+			ctx:        context.Background(),
+			key:        CallOptionsHeader,
+			want:       "client=my-integration",
+		},
+		{
+			name:       "Header",
+			middleware: Header("X-Audit", "nightly-sync"),
+			ctx:        context.Background(),
+			key:        "X-Audit",
+			want:       "nightly-sync",
+		},
+		{
+			name: "Header Func",
+			middleware: HeaderFunc("X-Correlation-Id", func(request *http.Request) string {
+				id, _ := request.Context().Value(correlationKey{}).(string)
+				return id
+			}),
+			ctx:  context.WithValue(context.Background(), correlationKey{}, "abc-123"),
+			key:  "X-Correlation-Id",
+			want: "abc-123",
+		},
+		{
+			name: "Header Func Empty",
+			middleware: HeaderFunc("X-Correlation-Id", func(request *http.Request) string {
+				return ""
+			}),
+			ctx:  context.Background(),
+			key:  "X-Correlation-Id",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var headers http.Header
+			transport := Chain(mockRecordingTransport(&headers), tt.middleware)
+
+			request, _ := http.NewRequestWithContext(tt.ctx, http.MethodGet, "https://test.salesforce.com", nil)
+			if _, err := transport.RoundTrip(request); err != nil {
+				t.Fatalf("Middleware error = %v", err)
+			}
+			if got := headers.Get(tt.key); got != tt.want {
+				t.Errorf("Middleware header %s = %s, want %s", tt.key, got, tt.want)
+			}
+			if request.Header.Get(tt.key) != "" {
+				t.Errorf("Middleware changed the original request header %s", tt.key)
+			}
+		})
+	}
+}
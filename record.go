@@ -1,6 +1,7 @@
 package sfdc
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 )
@@ -19,6 +20,8 @@ type Record struct {
 	url     string
 	fields  map[string]interface{}
 	lookUps map[string]*Record
+	nulls   map[string]struct{}
+	numbers map[string]json.Number
 }
 
 // RecordFromJSONMap creates a recrod from a JSON map.
@@ -41,7 +44,9 @@ func (r *Record) UnmarshalJSON(data []byte) error {
 		return errors.New("record: can't unmarshal to a nil byte array")
 	}
 	var jsonMap map[string]interface{}
-	err := json.Unmarshal(data, &jsonMap)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&jsonMap)
 	if err != nil {
 		return err
 	}
@@ -68,22 +73,46 @@ func (r *Record) fromJSONMap(jsonMap map[string]interface{}) {
 					}
 				}
 			}
-		} else {
-			if v != nil {
-				if obj, is := v.(map[string]interface{}); is == false {
-					r.fields[k] = v
-				} else {
-					if r.isLookUp(obj) {
-						if rec, err := RecordFromJSONMap(obj); err == nil {
-							r.lookUps[k] = rec
-						}
-					}
+			continue
+		}
+		switch value := v.(type) {
+		case nil:
+			r.setNull(k)
+		case json.Number:
+			r.setNumber(k, value)
+		case map[string]interface{}:
+			if r.isLookUp(value) {
+				if rec, err := RecordFromJSONMap(value); err == nil {
+					r.lookUps[k] = rec
 				}
 			}
+		default:
+			r.fields[k] = v
 		}
 	}
 }
 
+// setNull records that the field is null, so that the typed accessors are
+// able to tell a null field from an absent field.
+func (r *Record) setNull(field string) {
+	if r.nulls == nil {
+		r.nulls = make(map[string]struct{})
+	}
+	r.nulls[field] = struct{}{}
+}
+
+// setNumber keeps the exact JSON number for the decimal accessor, while the
+// field value remains a float64.
+func (r *Record) setNumber(field string, number json.Number) {
+	if value, err := number.Float64(); err == nil {
+		r.fields[field] = value
+	}
+	if r.numbers == nil {
+		r.numbers = make(map[string]json.Number)
+	}
+	r.numbers[field] = number
+}
+
 func (r *Record) isLookUp(jsonMap map[string]interface{}) bool {
 	_, has := jsonMap[RecordAttributes]
 	return has
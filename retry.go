@@ -0,0 +1,212 @@
+package sfdc
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrUnableToLockRow is the Salesforce error code when a record is locked
+// by another request.
+const ErrUnableToLockRow Code = "UNABLE_TO_LOCK_ROW"
+
+const (
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 10 * time.Second
+)
+
+// RetryClassifier decides if a request should be retried.  The status code and
+// Salesforce errors are from the response, and the error is from the HTTP
+// round trip.  Only one of the response or the error will be present.
+type RetryClassifier func(statusCode int, errs []Error, err error) bool
+
+// RetryPolicy is the policy for retrying transient failures.
+//
+// MaxAttempts is the total number of attempts, including the first.  If this
+// is less than two, requests are not retried.
+//
+// MinBackoff is the smallest backoff between attempts.  Each retry doubles the
+// upper bound of the backoff, and the backoff is jittered between the
+// MinBackoff and the bound.  If zero, 100 milliseconds is used.
+//
+// MaxBackoff is the largest backoff between attempts.  If zero, 10 seconds
+// is used.
+//
+// RetryNonIdempotent will allow POST requests to be retried.  This should only
+// be used when it is safe to send the request more than once.
+//
+// Classifier decides if the request should be retried.  If nil, the
+// DefaultRetryClassifier is used.
+type RetryPolicy struct {
+	MaxAttempts        int
+	MinBackoff         time.Duration
+	MaxBackoff         time.Duration
+	RetryNonIdempotent bool
+	Classifier         RetryClassifier
+}
+
+type retryTransport struct {
+	policy    *RetryPolicy
+	transport http.RoundTripper
+}
+
+// DefaultRetryClassifier will retry connection errors, 429 and 5xx gateway or
+// unavailable responses, and the UNABLE_TO_LOCK_ROW, REQUEST_LIMIT_EXCEEDED and
+// SERVER_UNAVAILABLE error codes.
+func DefaultRetryClassifier(statusCode int, errs []Error, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	for _, sfdcErr := range errs {
+		switch Code(sfdcErr.ErrorCode) {
+		case ErrUnableToLockRow, ErrRequestLimitExceeded, "SERVER_UNAVAILABLE":
+			return true
+		}
+	}
+	return false
+}
+
+// Transport will return a round tripper that retries requests with the policy.
+func (policy *RetryPolicy) Transport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &retryTransport{
+		policy:    policy,
+		transport: transport,
+	}
+}
+
+// Backoff will return the jittered backoff before the retry attempt.  The
+// first retry is attempt one.  The backoff is never less than the MinBackoff
+// or more than the MaxBackoff.
+func (policy *RetryPolicy) Backoff(attempt int) time.Duration {
+	maxBackoff := policy.maxBackoff()
+	minBackoff := policy.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	if minBackoff > maxBackoff {
+		minBackoff = maxBackoff
+	}
+	backoff := minBackoff
+	for idx := 1; idx < attempt && backoff < maxBackoff; idx++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return minBackoff + time.Duration(rand.Int63n(int64(backoff-minBackoff)+1))
+}
+
+func (policy *RetryPolicy) maxBackoff() time.Duration {
+	if policy.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+	return policy.MaxBackoff
+}
+
+func (policy *RetryPolicy) retryable(request *http.Request) bool {
+	if policy.MaxAttempts < 2 {
+		return false
+	}
+	if request.Method == http.MethodPost && policy.RetryNonIdempotent == false {
+		return false
+	}
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+func (policy *RetryPolicy) classifier() RetryClassifier {
+	if policy.Classifier != nil {
+		return policy.Classifier
+	}
+	return DefaultRetryClassifier
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.policy.retryable(request) == false {
+		return t.transport.RoundTrip(request)
+	}
+
+	classifier := t.policy.classifier()
+	attempt := request
+	for idx := 1; ; idx++ {
+		response, err := t.transport.RoundTrip(attempt)
+
+		var errs []Error
+		statusCode := 0
+		if err == nil {
+			statusCode = response.StatusCode
+			if statusCode < http.StatusBadRequest {
+				return response, nil
+			}
+			errs, err = bufferErrors(response)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		last := idx >= t.policy.MaxAttempts || request.Context().Err() != nil
+		if last || classifier(statusCode, errs, err) == false {
+			return response, err
+		}
+
+		backoff := t.policy.Backoff(idx)
+		if response != nil {
+			if after := retryAfter(response); after > backoff {
+				backoff = after
+				if backoff > t.policy.maxBackoff() {
+					backoff = t.policy.maxBackoff()
+				}
+			}
+			response.Body.Close()
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+
+		attempt = request.Clone(request.Context())
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+	}
+}
+
+// bufferErrors will read the response body to decode the Salesforce errors and
+// replace the body so that it is able to be read again.
+func bufferErrors(response *http.Response) ([]Error, error) {
+	if response.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return decodeErrors(body), nil
+}
+
+func retryAfter(response *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
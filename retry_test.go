@@ -0,0 +1,279 @@
+package sfdc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func mockFailingServer(failures int32, status int, body string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		if call <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		request, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write(request)
+	}))
+	return server, &calls
+}
+
+func TestRetryPolicy_Transport(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *RetryPolicy
+		failures   int32
+		status     int
+		body       string
+		method     string
+		request    string
+		wantStatus int
+		wantBody   string
+		wantCalls  int32
+	}{
+		{
+			name: "Retry Unavailable",
+			policy: &RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+			},
+			failures:   2,
+			status:     http.StatusServiceUnavailable,
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name: "Attempts Exceeded",
+			policy: &RetryPolicy{
+				MaxAttempts: 2,
+				MinBackoff:  time.Millisecond,
+			},
+			failures:   5,
+			status:     http.StatusServiceUnavailable,
+			body:       "unavailable",
+			method:     http.MethodGet,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "unavailable",
+			wantCalls:  2,
+		},
+		{
+			name: "Retry Error Code",
+			policy: &RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+			},
+			failures:   1,
+			status:     http.StatusBadRequest,
+			body:       `[{"message":"unable to obtain exclusive access to this record","errorCode":"UNABLE_TO_LOCK_ROW"}]`,
+			method:     http.MethodPatch,
+			request:    `{"Name":"patched"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"Name":"patched"}`,
+			wantCalls:  2,
+		},
+		{
+			name: "Not Retryable",
+			policy: &RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+			},
+			failures:   1,
+			status:     http.StatusBadRequest,
+			body:       `[{"message":"Required fields are missing: [Name]","errorCode":"REQUIRED_FIELD_MISSING"}]`,
+			method:     http.MethodGet,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"message":"Required fields are missing: [Name]","errorCode":"REQUIRED_FIELD_MISSING"}]`,
+			wantCalls:  1,
+		},
+		{
+			name: "POST Not Retried",
+			policy: &RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+			},
+			failures:   1,
+			status:     http.StatusServiceUnavailable,
+			method:     http.MethodPost,
+			request:    `{"Name":"posted"}`,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name: "POST Retried",
+			policy: &RetryPolicy{
+				MaxAttempts:        3,
+				MinBackoff:         time.Millisecond,
+				RetryNonIdempotent: true,
+			},
+			failures:   1,
+			status:     http.StatusServiceUnavailable,
+			method:     http.MethodPost,
+			request:    `{"Name":"posted"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"Name":"posted"}`,
+			wantCalls:  2,
+		},
+		{
+			name: "Custom Classifier",
+			policy: &RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+				Classifier: func(statusCode int, errs []Error, err error) bool {
+					return statusCode == http.StatusNotFound
+				},
+			},
+			failures:   1,
+			status:     http.StatusNotFound,
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := mockFailingServer(tt.failures, tt.status, tt.body)
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: tt.policy.Transport(server.Client().Transport),
+			}
+			var request *http.Request
+			var err error
+			if tt.request == "" {
+				request, err = http.NewRequest(tt.method, server.URL, nil)
+			} else {
+				request, err = http.NewRequest(tt.method, server.URL, strings.NewReader(tt.request))
+			}
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			response, err := client.Do(request)
+			if err != nil {
+				t.Fatalf("RetryPolicy.Transport() error = %v", err)
+			}
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("RetryPolicy.Transport() status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("RetryPolicy.Transport() body = %s, want %s", string(body), tt.wantBody)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("RetryPolicy.Transport() calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Transport_Context(t *testing.T) {
+	server, calls := mockFailingServer(5, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	policy := &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  time.Second,
+	}
+	client := &http.Client{
+		Transport: policy.Transport(server.Client().Transport),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	_, err := client.Do(request)
+	if errors.Is(err, context.DeadlineExceeded) == false {
+		t.Errorf("RetryPolicy.Transport() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if ctx.Err() == nil {
+		t.Error("RetryPolicy.Transport() returned before the context was done")
+	}
+	if *calls != 1 {
+		t.Errorf("RetryPolicy.Transport() calls = %d, want 1", *calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+	}
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 10 * time.Millisecond, max: 10 * time.Millisecond},
+		{attempt: 2, min: 10 * time.Millisecond, max: 20 * time.Millisecond},
+		{attempt: 3, min: 10 * time.Millisecond, max: 40 * time.Millisecond},
+		{attempt: 10, min: 10 * time.Millisecond, max: 40 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for idx := 0; idx < 20; idx++ {
+			if got := policy.Backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("RetryPolicy.Backoff(%d) = %v, want [%v, %v]", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+
+	policy = &RetryPolicy{
+		MinBackoff: time.Minute,
+		MaxBackoff: time.Second,
+	}
+	if got := policy.Backoff(1); got != time.Second {
+		t.Errorf("RetryPolicy.Backoff(1) = %v, want %v", got, time.Second)
+	}
+}
+
+func TestDefaultRetryClassifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		errs       []Error
+		err        error
+		want       bool
+	}{
+		{
+			name: "Connection Error",
+			err:  ErrInvalidSessionID,
+			want: true,
+		},
+		{
+			name:       "Too Many Requests",
+			statusCode: http.StatusTooManyRequests,
+			want:       true,
+		},
+		{
+			name:       "Request Limit",
+			statusCode: http.StatusForbidden,
+			errs:       []Error{{ErrorCode: "REQUEST_LIMIT_EXCEEDED"}},
+			want:       true,
+		},
+		{
+			name:       "Bad Request",
+			statusCode: http.StatusBadRequest,
+			errs:       []Error{{ErrorCode: "INVALID_FIELD"}},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultRetryClassifier(tt.statusCode, tt.errs, tt.err); got != tt.want {
+				t.Errorf("DefaultRetryClassifier() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+)
+
+const (
+	cliDirectory     = ".sfdx"
+	cliAliasFile     = "alias.json"
+	cliV2Directory   = ".sf"
+	cliDefaultClient = "PlatformCLI"
+	cliDefaultLogin  = "https://login.salesforce.com"
+)
+
+// cliEncrypted matches the format of the tokens that the CLI has encrypted
+// with the key stored in the OS keychain.
+var cliEncrypted = regexp.MustCompile(`^[0-9a-fA-F]+:[0-9a-fA-F]+$`)
+
+type cliAuthorization struct {
+	AccessToken        string `json:"accessToken"`
+	RefreshToken       string `json:"refreshToken"`
+	InstanceURL        string `json:"instanceUrl"`
+	LoginURL           string `json:"loginUrl"`
+	ClientID           string `json:"clientId"`
+	ClientSecret       string `json:"clientSecret"`
+	Username           string `json:"username"`
+	OrgID              string `json:"orgId"`
+	InstanceAPIVersion string `json:"instanceApiVersion"`
+}
+
+type cliAliases struct {
+	Orgs map[string]string `json:"orgs"`
+}
+
+// FromCLI is used to create a session from an org that has been authenticated with the
+// Salesforce CLI.  The org is found by its alias or username in the CLI's auth files in the
+// user's home directory.  If the configuration does not have credentials, the CLI's refresh
+// token will be used to refresh the session.  If the configuration version is not set, the
+// org's API version that the CLI has stored will be used.
+func FromCLI(config sfdc.Configuration, aliasOrUsername string) (*Session, error) {
+	if aliasOrUsername == "" {
+		return nil, errors.New("session cli: alias or username can not be empty")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("session cli: %w", err)
+	}
+
+	username, err := cliUsername(home, aliasOrUsername)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := cliAuthorizationFile(home, username)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case auth.AccessToken == "":
+		return nil, fmt.Errorf("session cli: %s does not have an access token", username)
+	case auth.InstanceURL == "":
+		return nil, fmt.Errorf("session cli: %s does not have an instance URL", username)
+	case cliEncrypted.MatchString(auth.AccessToken):
+		return nil, fmt.Errorf("session cli: the tokens for %s are encrypted by the CLI and can not be read", username)
+	}
+
+	if config.Version <= 0 {
+		version, err := strconv.ParseFloat(auth.InstanceAPIVersion, 64)
+		if err == nil {
+			config.Version = int(version)
+		}
+	}
+
+	if config.Credentials == nil && auth.RefreshToken != "" {
+		loginURL := auth.LoginURL
+		if loginURL == "" {
+			loginURL = cliDefaultLogin
+		}
+		clientID := auth.ClientID
+		if clientID == "" {
+			clientID = cliDefaultClient
+		}
+		config.Credentials, err = credentials.NewRefreshTokenCredentials(credentials.RefreshTokenCredentials{
+			URL:          strings.TrimSuffix(loginURL, "/"),
+			ClientID:     clientID,
+			ClientSecret: auth.ClientSecret,
+			RefreshToken: auth.RefreshToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return FromToken(config, Token{
+		AccessToken: auth.AccessToken,
+		InstanceURL: strings.TrimSuffix(auth.InstanceURL, "/"),
+	})
+}
+
+// cliUsername will return the username of the alias.  If the alias is not
+// found, then it is assumed to be a username.
+func cliUsername(home, aliasOrUsername string) (string, error) {
+	aliasFiles := []string{
+		filepath.Join(home, cliV2Directory, cliAliasFile),
+		filepath.Join(home, cliDirectory, cliAliasFile),
+	}
+	for _, aliasFile := range aliasFiles {
+		data, err := ioutil.ReadFile(aliasFile)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("session cli: %w", err)
+		}
+		var aliases cliAliases
+		if err := json.Unmarshal(data, &aliases); err != nil {
+			return "", fmt.Errorf("session cli: %s %w", aliasFile, err)
+		}
+		if username, has := aliases.Orgs[aliasOrUsername]; has {
+			return username, nil
+		}
+	}
+	return aliasOrUsername, nil
+}
+
+func cliAuthorizationFile(home, username string) (*cliAuthorization, error) {
+	if filepath.Base(username) != username {
+		return nil, fmt.Errorf("session cli: %s is not a valid username", username)
+	}
+	authFile := filepath.Join(home, cliDirectory, username+".json")
+	data, err := ioutil.ReadFile(authFile)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("session cli: %s has not been authenticated with the CLI", username)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("session cli: %w", err)
+	}
+
+	var auth cliAuthorization
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, fmt.Errorf("session cli: %s %w", authFile, err)
+	}
+	return &auth, nil
+}
@@ -0,0 +1,173 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func testCLIHome(t *testing.T, files map[string]string) {
+	home, err := ioutil.TempDir("", "sfdc-cli")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(home)
+	})
+	for name, content := range files {
+		path := filepath.Join(home, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	t.Setenv("HOME", home)
+}
+
+func TestFromCLI(t *testing.T) {
+	authFile := `
+	{
+		"accessToken": "00D!token",
+		"instanceUrl": "https://mydomain.my.salesforce.com/",
+		"loginUrl": "https://login.salesforce.com",
+		"orgId": "00D",
+		"username": "my.user@name.com",
+		"clientId": "PlatformCLI",
+		"refreshToken": "5Aep!refresh",
+		"instanceApiVersion": "58.0"
+	}`
+	tests := []struct {
+		name           string
+		files          map[string]string
+		config         sfdc.Configuration
+		alias          string
+		wantServiceURL string
+		wantRefresh    bool
+		wantErr        bool
+	}{
+		{
+			name: "Alias",
+			files: map[string]string{
+				".sfdx/alias.json":            `{"orgs":{"dev":"my.user@name.com"}}`,
+				".sfdx/my.user@name.com.json": authFile,
+			},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:          "dev",
+			wantServiceURL: "https://mydomain.my.salesforce.com/services/data/v45.0",
+			wantRefresh:    true,
+			wantErr:        false,
+		},
+		{
+			name: "SF Alias",
+			files: map[string]string{
+				".sf/alias.json":              `{"orgs":{"dev":"my.user@name.com"}}`,
+				".sfdx/my.user@name.com.json": authFile,
+			},
+			config: sfdc.Configuration{
+				Client: http.DefaultClient,
+			},
+			alias:          "dev",
+			wantServiceURL: "https://mydomain.my.salesforce.com/services/data/v58.0",
+			wantRefresh:    true,
+			wantErr:        false,
+		},
+		{
+			name: "Username",
+			files: map[string]string{
+				".sfdx/my.user@name.com.json": `{"accessToken":"00D!token","instanceUrl":"https://mydomain.my.salesforce.com"}`,
+			},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:          "my.user@name.com",
+			wantServiceURL: "https://mydomain.my.salesforce.com/services/data/v45.0",
+			wantRefresh:    false,
+			wantErr:        false,
+		},
+		{
+			name:  "Not Authenticated",
+			files: map[string]string{},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:   "dev",
+			wantErr: true,
+		},
+		{
+			name: "Encrypted",
+			files: map[string]string{
+				".sfdx/my.user@name.com.json": `{"accessToken":"a1b2c3d4e5f6:0a1b2c3d","instanceUrl":"https://mydomain.my.salesforce.com"}`,
+			},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:   "my.user@name.com",
+			wantErr: true,
+		},
+		{
+			name: "Bad Auth File",
+			files: map[string]string{
+				".sfdx/my.user@name.com.json": `{"accessToken":`,
+			},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:   "my.user@name.com",
+			wantErr: true,
+		},
+		{
+			name: "Not A Username",
+			files: map[string]string{
+				".sfdx/alias.json": `{"orgs":{"dev":"../my.user@name.com"}}`,
+			},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:   "dev",
+			wantErr: true,
+		},
+		{
+			name:  "No Alias",
+			files: map[string]string{},
+			config: sfdc.Configuration{
+				Client:  http.DefaultClient,
+				Version: 45,
+			},
+			alias:   "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCLIHome(t, tt.files)
+			got, err := FromCLI(tt.config, tt.alias)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromCLI() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ServiceURL() != tt.wantServiceURL {
+				t.Errorf("FromCLI() service URL = %s, want %s", got.ServiceURL(), tt.wantServiceURL)
+			}
+			if got.refreshable() != tt.wantRefresh {
+				t.Errorf("FromCLI() refreshable = %t, want %t", got.refreshable(), tt.wantRefresh)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package session
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+const revokeEndpoint = "/services/oauth2/revoke"
+
+// Identity is the identity of the user that the session is running as.
+//
+// ID is the identity URL.
+//
+// UserID is the Salesforce user ID.
+//
+// OrganizationID is the Salesforce org ID.
+//
+// URLs are the org's API endpoints, like enterprise, rest and sobjects.
+type Identity struct {
+	ID             string            `json:"id"`
+	AssertedUser   bool              `json:"asserted_user"`
+	UserID         string            `json:"user_id"`
+	OrganizationID string            `json:"organization_id"`
+	Username       string            `json:"username"`
+	NickName       string            `json:"nick_name"`
+	DisplayName    string            `json:"display_name"`
+	Email          string            `json:"email"`
+	Active         bool              `json:"active"`
+	UserType       string            `json:"user_type"`
+	Language       string            `json:"language"`
+	Locale         string            `json:"locale"`
+	Timezone       string            `json:"timezone"`
+	URLs           map[string]string `json:"urls"`
+}
+
+// Identity will return the identity of the session's user from the
+// identity URL.
+func (session *Session) Identity() (*Identity, error) {
+	return session.IdentityWithContext(context.Background())
+}
+
+// IdentityWithContext will return the identity of the session's user using the context
+// for the HTTP request.
+func (session *Session) IdentityWithContext(ctx context.Context) (*Identity, error) {
+	session.mutex.RLock()
+	identityURL := session.response.ID
+	session.mutex.RUnlock()
+	if identityURL == "" {
+		return nil, errors.New("session: identity URL is not available")
+	}
+
+	request, err := session.identityRequest(ctx, identityURL)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+
+	if response.StatusCode != http.StatusOK {
+		return nil, sfdc.NewAPIError(response)
+	}
+
+	var identity Identity
+	if err := json.NewDecoder(response.Body).Decode(&identity); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (session *Session) identityRequest(ctx context.Context, identityURL string) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, identityURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	session.AuthorizationHeader(request)
+	return request, nil
+}
+
+// Revoke will revoke the session's access token.  After the token has
+// been revoked, the session should no longer be used.
+func (session *Session) Revoke() error {
+	return session.RevokeWithContext(context.Background())
+}
+
+// RevokeWithContext will revoke the session's access token using the context
+// for the HTTP request.
+func (session *Session) RevokeWithContext(ctx context.Context) error {
+	session.mutex.RLock()
+	revokeURL := session.response.InstanceURL + revokeEndpoint
+	form := url.Values{}
+	form.Add("token", session.response.AccessToken)
+	session.mutex.RUnlock()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := session.config.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(response)
+
+	if response.StatusCode != http.StatusOK {
+		return sfdc.NewAPIError(response)
+	}
+	return nil
+}
+
+// VerifySignature will verify the session's signature with the connected
+// application's client secret.  The signature is the HMAC-SHA256 of the
+// identity URL and the issued at time.
+func (session *Session) VerifySignature(clientSecret string) error {
+	session.mutex.RLock()
+	id := session.response.ID
+	issuedAt := session.response.IssuedAt
+	signature := session.response.Signature
+	session.mutex.RUnlock()
+
+	if signature == "" {
+		return errors.New("session: signature is not available")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("session: signature can not be decoded: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(id + issuedAt))
+	if hmac.Equal(mac.Sum(nil), decoded) == false {
+		return errors.New("session: signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package session
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func testIdentitySession(response *sessionPasswordResponse, client *http.Client) *Session {
+	session := &Session{
+		response: response,
+		config: sfdc.Configuration{
+			Client:  client,
+			Version: 45,
+		},
+	}
+	session.client = session.newClient()
+	return session
+}
+
+func testSignature(secret, id, issuedAt string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + issuedAt))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestSession_Identity(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *sessionPasswordResponse
+		client   *http.Client
+		want     *Identity
+		wantErr  bool
+	}{
+		{
+			name: "Passing",
+			response: &sessionPasswordResponse{
+				AccessToken: "token",
+				InstanceURL: "https://some.salesforce.instance.com",
+				ID:          "https://login.salesforce.com/id/00D/005",
+				TokenType:   "Bearer",
+			},
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				if req.URL.String() != "https://login.salesforce.com/id/00D/005" || req.Header.Get("Authorization") != "Bearer token" {
+					return &http.Response{
+						StatusCode: 500,
+						Status:     "Invalid Request",
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+						Header:     make(http.Header),
+					}
+				}
+				resp := `
+				{
+					"id": "https://login.salesforce.com/id/00D/005",
+					"user_id": "005",
+					"organization_id": "00D",
+					"username": "my.user@name.com",
+					"display_name": "My User",
+					"active": true,
+					"locale": "en_US",
+					"timezone": "America/Los_Angeles",
+					"urls": {
+						"rest": "https://some.salesforce.instance.com/services/data/v{version}/"
+					}
+				}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "Some Status",
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			}),
+			want: &Identity{
+				ID:             "https://login.salesforce.com/id/00D/005",
+				UserID:         "005",
+				OrganizationID: "00D",
+				Username:       "my.user@name.com",
+				DisplayName:    "My User",
+				Active:         true,
+				Locale:         "en_US",
+				Timezone:       "America/Los_Angeles",
+				URLs: map[string]string{
+					"rest": "https://some.salesforce.instance.com/services/data/v{version}/",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "No Identity URL",
+			response: &sessionPasswordResponse{
+				AccessToken: "token",
+				InstanceURL: "https://some.salesforce.instance.com",
+				TokenType:   "Bearer",
+			},
+			client:  http.DefaultClient,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Response Error",
+			response: &sessionPasswordResponse{
+				AccessToken: "token",
+				InstanceURL: "https://some.salesforce.instance.com",
+				ID:          "https://login.salesforce.com/id/00D/005",
+				TokenType:   "Bearer",
+			},
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Status:     "Bad_OAuth_Token",
+					Body:       ioutil.NopCloser(strings.NewReader("Bad_OAuth_Token")),
+					Header:     make(http.Header),
+				}
+			}),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := testIdentitySession(tt.response, tt.client)
+			got, err := session.IdentityWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Session.Identity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Session.Identity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_Revoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *http.Client
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				req.ParseForm()
+				if req.Method != http.MethodPost ||
+					req.URL.String() != "https://some.salesforce.instance.com"+revokeEndpoint ||
+					req.PostForm.Get("token") != "token" {
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Status:     "unsupported_token_type",
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+						Header:     make(http.Header),
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "Some Status",
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}
+			}),
+			wantErr: false,
+		},
+		{
+			name: "Response Error",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "unsupported_token_type",
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := testIdentitySession(&sessionPasswordResponse{
+				AccessToken: "token",
+				InstanceURL: "https://some.salesforce.instance.com",
+				TokenType:   "Bearer",
+			}, tt.client)
+			if err := session.RevokeWithContext(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("Session.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSession_VerifySignature(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     *sessionPasswordResponse
+		clientSecret string
+		wantErr      bool
+	}{
+		{
+			name: "Passing",
+			response: &sessionPasswordResponse{
+				ID:        "https://login.salesforce.com/id/00D/005",
+				IssuedAt:  "1553568410028",
+				Signature: testSignature("shhhh its a secret", "https://login.salesforce.com/id/00D/005", "1553568410028"),
+			},
+			clientSecret: "shhhh its a secret",
+			wantErr:      false,
+		},
+		{
+			name: "Wrong Secret",
+			response: &sessionPasswordResponse{
+				ID:        "https://login.salesforce.com/id/00D/005",
+				IssuedAt:  "1553568410028",
+				Signature: testSignature("shhhh its a secret", "https://login.salesforce.com/id/00D/005", "1553568410028"),
+			},
+			clientSecret: "not the secret",
+			wantErr:      true,
+		},
+		{
+			name: "No Signature",
+			response: &sessionPasswordResponse{
+				ID:       "https://login.salesforce.com/id/00D/005",
+				IssuedAt: "1553568410028",
+			},
+			clientSecret: "shhhh its a secret",
+			wantErr:      true,
+		},
+		{
+			name: "Signature Not Encoded",
+			response: &sessionPasswordResponse{
+				ID:        "https://login.salesforce.com/id/00D/005",
+				IssuedAt:  "1553568410028",
+				Signature: "hello!",
+			},
+			clientSecret: "shhhh its a secret",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{
+				response: tt.response,
+			}
+			if err := session.VerifySignature(tt.clientSecret); (err != nil) != tt.wantErr {
+				t.Errorf("Session.VerifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
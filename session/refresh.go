@@ -0,0 +1,74 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// refreshTransport will refresh the session when a response is returned
+// as unauthorized and replay the original request once with the new
+// access token.
+type refreshTransport struct {
+	session   *Session
+	transport http.RoundTripper
+}
+
+func (t *refreshTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusUnauthorized || t.replayable(request) == false {
+		return response, nil
+	}
+
+	if err := t.session.refresh(request.Context(), request.Header.Get("Authorization")); err != nil {
+		closeResponse(response)
+		return nil, fmt.Errorf("session refresh: %w", err)
+	}
+
+	replay, err := t.replay(request)
+	if err != nil {
+		closeResponse(response)
+		return nil, err
+	}
+	closeResponse(response)
+
+	return t.transport.RoundTrip(replay)
+}
+
+// replayable checks that the request was authorized by the session and
+// that the request body, if any, can be sent again.
+func (t *refreshTransport) replayable(request *http.Request) bool {
+	if request.Header.Get("Authorization") == "" || t.session.refreshable() == false {
+		return false
+	}
+	if request.Body == nil || request.Body == http.NoBody {
+		return true
+	}
+	return request.GetBody != nil
+}
+
+func (t *refreshTransport) replay(request *http.Request) (*http.Request, error) {
+	replay := request.Clone(request.Context())
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		replay.Body = body
+	}
+	replay.Header.Del("Authorization")
+	t.session.AuthorizationHeader(replay)
+	return replay, nil
+}
+
+func closeResponse(response *http.Response) {
+	if response.Body == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+}
@@ -0,0 +1,358 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+)
+
+func mockRefreshClient(tokens *int32, tokenStatus int) *http.Client {
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path == oauthEndpoint {
+			atomic.AddInt32(tokens, 1)
+			resp := `
+			{
+				"access_token": "new",
+				"instance_url": "https://some.salesforce.instance.com",
+				"id": "https://test.salesforce.com/id/123456789",
+				"token_type": "Bearer",
+				"issued_at": "1553568410028",
+				"signature": "hello"
+			}`
+			return &http.Response{
+				StatusCode: tokenStatus,
+				Status:     "Token Status",
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}
+		}
+		if req.Header.Get("Authorization") != "Bearer new" {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Status:     "Unauthorized",
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`)),
+				Header:     make(http.Header),
+			}
+		}
+		body := ""
+		if req.Body != nil {
+			b, _ := ioutil.ReadAll(req.Body)
+			body = string(b)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func testRefreshSession(client *http.Client) *Session {
+	session := &Session{
+		response: &sessionPasswordResponse{
+			AccessToken: "old",
+			InstanceURL: "https://some.salesforce.instance.com",
+			TokenType:   "Bearer",
+		},
+		config: sfdc.Configuration{
+			Credentials: testNewPasswordCredentials(credentials.PasswordCredentials{
+				URL:          "http://test.password.session",
+				Username:     "myusername",
+				Password:     "12345",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+			}),
+			Client:  client,
+			Version: 45,
+		},
+	}
+	session.client = session.newClient()
+	return session
+}
+
+func TestSession_Client_Refresh(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenStatus int
+		body        func() *strings.Reader
+		rawBody     bool
+		wantStatus  int
+		wantBody    string
+		wantTokens  int32
+		wantErr     bool
+	}{
+		{
+			name:        "Refresh and Replay",
+			tokenStatus: http.StatusOK,
+			wantStatus:  http.StatusOK,
+			wantBody:    "",
+			wantTokens:  1,
+		},
+		{
+			name:        "Refresh and Replay Body",
+			tokenStatus: http.StatusOK,
+			body: func() *strings.Reader {
+				return strings.NewReader(`{"Name":"replayed"}`)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"Name":"replayed"}`,
+			wantTokens: 1,
+		},
+		{
+			name:        "Body Not Replayable",
+			tokenStatus: http.StatusOK,
+			body: func() *strings.Reader {
+				return strings.NewReader(`{"Name":"replayed"}`)
+			},
+			rawBody:    true,
+			wantStatus: http.StatusUnauthorized,
+			wantTokens: 0,
+		},
+		{
+			name:        "Refresh Error",
+			tokenStatus: http.StatusBadRequest,
+			wantTokens:  1,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokens int32
+			session := testRefreshSession(mockRefreshClient(&tokens, tt.tokenStatus))
+
+			var request *http.Request
+			var err error
+			switch {
+			case tt.body == nil:
+				request, err = http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+			case tt.rawBody:
+				request, err = http.NewRequest(http.MethodPost, session.ServiceURL()+"/sobjects/Account", ioutil.NopCloser(tt.body()))
+			default:
+				request, err = http.NewRequest(http.MethodPost, session.ServiceURL()+"/sobjects/Account", tt.body())
+			}
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			session.AuthorizationHeader(request)
+
+			response, err := session.Client().Do(request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Session.Client().Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tokens != tt.wantTokens {
+				t.Errorf("Session.Client().Do() token requests = %d, want %d", tokens, tt.wantTokens)
+			}
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("Session.Client().Do() status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			body, _ := ioutil.ReadAll(response.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("Session.Client().Do() body = %s, want %s", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSession_Client_ConcurrentRefresh(t *testing.T) {
+	var tokens int32
+	session := testRefreshSession(mockRefreshClient(&tokens, http.StatusOK))
+
+	var hooks int32
+	session.OnRefresh(func(s *Session) {
+		atomic.AddInt32(&hooks, 1)
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		request, err := http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		session.AuthorizationHeader(request)
+		wg.Add(1)
+		go func(request *http.Request) {
+			defer wg.Done()
+			response, err := session.Client().Do(request)
+			if err != nil {
+				errs <- err
+				return
+			}
+			response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				errs <- &http.ProtocolError{ErrorString: response.Status}
+			}
+		}(request)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Session.Client().Do() error = %v", err)
+	}
+	if tokens != 1 {
+		t.Errorf("Session.Client().Do() token requests = %d, want 1", tokens)
+	}
+	if hooks != 1 {
+		t.Errorf("Session.OnRefresh() hook calls = %d, want 1", hooks)
+	}
+}
+
+func TestSession_RefreshWithContext_InFlight(t *testing.T) {
+	var tokens int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	refresh := mockRefreshClient(&tokens, http.StatusOK)
+	session := testRefreshSession(mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path == oauthEndpoint {
+			close(started)
+			<-release
+		}
+		response, _ := refresh.Transport.RoundTrip(req)
+		return response
+	}))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Refresh()
+	}()
+	<-started
+
+	if url := session.InstanceURL(); url != "https://some.salesforce.instance.com" {
+		t.Errorf("Session.InstanceURL() = %s, want %s", url, "https://some.salesforce.instance.com")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := session.RefreshWithContext(ctx); err != context.Canceled {
+		t.Errorf("Session.RefreshWithContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Session.Refresh() error = %v", err)
+	}
+	if tokens != 1 {
+		t.Errorf("Session.Refresh() token requests = %d, want 1", tokens)
+	}
+	if token := session.authorization(); token != "Bearer new" {
+		t.Errorf("Session.Refresh() authorization = %s, want %s", token, "Bearer new")
+	}
+}
+
+func TestSession_IssuedAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuedAt string
+		want     time.Time
+	}{
+		{
+			name:     "Issued At",
+			issuedAt: "1553568410028",
+			want:     time.Unix(1553568410, 28*int64(time.Millisecond)),
+		},
+		{
+			name:     "Not Present",
+			issuedAt: "",
+			want:     time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{
+				response: &sessionPasswordResponse{
+					IssuedAt: tt.issuedAt,
+				},
+			}
+			if got := session.IssuedAt(); got.Equal(tt.want) == false {
+				t.Errorf("Session.IssuedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_Client_Retry(t *testing.T) {
+	var calls int32
+	session := testRefreshSession(mockHTTPClient(func(req *http.Request) *http.Response {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Status:     "Unavailable",
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}
+	}))
+	session.config.RetryPolicy = &sfdc.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+	session.client = session.newClient()
+
+	request, _ := http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+	session.AuthorizationHeader(request)
+	response, err := session.Client().Do(request)
+	if err != nil {
+		t.Fatalf("Session.Client().Do() error = %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Session.Client().Do() status = %d, want %d", response.StatusCode, http.StatusOK)
+	}
+	if calls != 2 {
+		t.Errorf("Session.Client().Do() calls = %d, want 2", calls)
+	}
+}
+
+func TestSession_Use(t *testing.T) {
+	var tokens int32
+	session := testRefreshSession(mockRefreshClient(&tokens, http.StatusOK))
+	session.config.Middleware = []sfdc.Middleware{sfdc.CallOptions("my-integration")}
+	session.client = session.newClient()
+
+	var seen []string
+	session.Use(func(next http.RoundTripper) http.RoundTripper {
+		return sfdc.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			seen = append(seen, request.Header.Get(sfdc.CallOptionsHeader)+" "+request.Header.Get("Authorization"))
+			return next.RoundTrip(request)
+		})
+	})
+
+	request, _ := http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+	session.AuthorizationHeader(request)
+	response, err := session.Client().Do(request)
+	if err != nil {
+		t.Fatalf("Session.Client().Do() error = %v", err)
+	}
+	response.Body.Close()
+
+	want := []string{"client=my-integration Bearer old", "client=my-integration Bearer new"}
+	if len(seen) != len(want) {
+		t.Fatalf("Session.Use() requests = %v, want %v", seen, want)
+	}
+	for idx := range want {
+		if seen[idx] != want[idx] {
+			t.Errorf("Session.Use() request %d = %s, want %s", idx, seen[idx], want[idx])
+		}
+	}
+}
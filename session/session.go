@@ -2,10 +2,14 @@
 package session
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/g8rswimmer/go-sfdc"
 	"github.com/g8rswimmer/go-sfdc/credentials"
@@ -14,10 +18,28 @@ import (
 // Session is the authentication response.  This is used to generate the
 // authroization header for the Salesforce API calls.
 type Session struct {
-	response *sessionPasswordResponse
-	config   sfdc.Configuration
+	response     *sessionPasswordResponse
+	config       sfdc.Configuration
+	client       *http.Client
+	mutex        sync.RWMutex
+	refreshHooks []RefreshHook
+	refreshing   *refreshCall
+	usageMutex   sync.Mutex
+	usage        APIUsage
+	thresholds   []*usageThreshold
 }
 
+// refreshCall is the token refresh that is in flight.  The callers that
+// wait on the refresh share its error once done is closed.
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// RefreshHook is called after the session's access token has
+// been refreshed.
+type RefreshHook func(session *Session)
+
 // Clienter interface provides the HTTP client used by the
 // the resources.
 type Clienter interface {
@@ -49,12 +71,13 @@ type ServiceFormatter interface {
 }
 
 type sessionPasswordResponse struct {
-	AccessToken string `json:"access_token"`
-	InstanceURL string `json:"instance_url"`
-	ID          string `json:"id"`
-	TokenType   string `json:"token_type"`
-	IssuedAt    string `json:"issued_at"`
-	Signature   string `json:"signature"`
+	AccessToken  string `json:"access_token"`
+	InstanceURL  string `json:"instance_url"`
+	ID           string `json:"id"`
+	TokenType    string `json:"token_type"`
+	IssuedAt     string `json:"issued_at"`
+	Signature    string `json:"signature"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 const oauthEndpoint = "/services/oauth2/token"
@@ -62,22 +85,22 @@ const oauthEndpoint = "/services/oauth2/token"
 // Open is used to authenticate with Salesforce and open a session.  The user will need to
 // supply the proper credentials and a HTTP client.
 func Open(config sfdc.Configuration) (*Session, error) {
+	return OpenWithContext(context.Background(), config)
+}
+
+// OpenWithContext is used to authenticate with Salesforce and open a session using
+// the context for the authentication request.
+func OpenWithContext(ctx context.Context, config sfdc.Configuration) (*Session, error) {
 	if config.Credentials == nil {
 		return nil, errors.New("session: configuration crendentials can not be nil")
 	}
 	if config.Client == nil {
 		return nil, errors.New("session: configuration client can not be nil")
 	}
-	if config.Version <= 0 {
+	if config.Version <= 0 && config.VersionPolicy != sfdc.VersionLatest {
 		return nil, errors.New("session: configuration version can not be less than zero")
 	}
-	request, err := passwordSessionRequest(config.Credentials)
-
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := passwordSessionResponse(request, config.Client)
+	response, err := authenticate(ctx, config.Credentials, config.Client)
 	if err != nil {
 		return nil, err
 	}
@@ -86,11 +109,55 @@ func Open(config sfdc.Configuration) (*Session, error) {
 		response: response,
 		config:   config,
 	}
+	session.client = session.newClient()
 
+	if err := session.negotiate(ctx); err != nil {
+		return nil, err
+	}
 	return session, nil
 }
 
-func passwordSessionRequest(creds *credentials.Credentials) (*http.Request, error) {
+func (session *Session) newClient() *http.Client {
+	client := *session.config.Client
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if session.config.RetryPolicy != nil {
+		transport = session.config.RetryPolicy.Transport(transport)
+	}
+	client.Transport = &refreshTransport{
+		session: session,
+		transport: &usageTransport{
+			session:   session,
+			transport: sfdc.Chain(transport, session.config.Middleware...),
+		},
+	}
+	return &client
+}
+
+// authenticate will retrieve the session from the OAuth endpoint.  If a new
+// refresh token is issued, the credentials will be rotated.
+func authenticate(ctx context.Context, creds *credentials.Credentials, client *http.Client) (*sessionPasswordResponse, error) {
+	request, err := passwordSessionRequest(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := passwordSessionResponse(request, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.RefreshToken != "" {
+		if err := creds.Rotate(response.RefreshToken); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func passwordSessionRequest(ctx context.Context, creds *credentials.Credentials) (*http.Request, error) {
 
 	oauthURL := creds.URL() + oauthEndpoint
 
@@ -99,7 +166,7 @@ func passwordSessionRequest(creds *credentials.Credentials) (*http.Request, erro
 		return nil, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, oauthURL, body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, oauthURL, body)
 
 	if err != nil {
 		return nil, err
@@ -116,11 +183,12 @@ func passwordSessionResponse(request *http.Request, client *http.Client) (*sessi
 		return nil, err
 	}
 
+	defer response.Body.Close()
+
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("session response error: %d %s", response.StatusCode, response.Status)
+		return nil, sfdc.NewAPIError(response)
 	}
 	decoder := json.NewDecoder(response.Body)
-	defer response.Body.Close()
 
 	var sessionResponse sessionPasswordResponse
 	err = decoder.Decode(&sessionResponse)
@@ -134,23 +202,143 @@ func passwordSessionResponse(request *http.Request, client *http.Client) (*sessi
 // InstanceURL will retuern the Salesforce instance
 // from the session authentication.
 func (session *Session) InstanceURL() string {
+	session.mutex.RLock()
+	defer session.mutex.RUnlock()
 	return session.response.InstanceURL
 }
 
 // ServiceURL will return the Salesforce instance for the
 // service URL.
 func (session *Session) ServiceURL() string {
+	session.mutex.RLock()
+	defer session.mutex.RUnlock()
 	return fmt.Sprintf("%s/services/data/v%d.0", session.response.InstanceURL, session.config.Version)
 }
 
 // AuthorizationHeader will add the authorization to the
 // HTTP request's header.
 func (session *Session) AuthorizationHeader(request *http.Request) {
-	auth := fmt.Sprintf("%s %s", session.response.TokenType, session.response.AccessToken)
-	request.Header.Add("Authorization", auth)
+	session.mutex.RLock()
+	defer session.mutex.RUnlock()
+	request.Header.Add("Authorization", session.authorization())
 }
 
-// Client returns the HTTP client to be used in APIs calls.
+func (session *Session) authorization() string {
+	return fmt.Sprintf("%s %s", session.response.TokenType, session.response.AccessToken)
+}
+
+// Client returns the HTTP client to be used in APIs calls.  The client
+// will refresh the session and replay the request when a response
+// is returned as unauthorized.
 func (session *Session) Client() *http.Client {
-	return session.config.Client
+	session.mutex.RLock()
+	defer session.mutex.RUnlock()
+	return session.client
+}
+
+// Use will add the middleware to the end of the session's chain.  Each API
+// request and response will flow through the middleware.
+func (session *Session) Use(middleware ...sfdc.Middleware) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	chain := make([]sfdc.Middleware, 0, len(session.config.Middleware)+len(middleware))
+	chain = append(chain, session.config.Middleware...)
+	session.config.Middleware = append(chain, middleware...)
+	session.client = session.newClient()
+}
+
+// IssuedAt returns the time that the current access token was issued.  If
+// Salesforce did not return the issued time, then the zero time is returned.
+func (session *Session) IssuedAt() time.Time {
+	session.mutex.RLock()
+	defer session.mutex.RUnlock()
+	millis, err := strconv.ParseInt(session.response.IssuedAt, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+// OnRefresh will register a hook that is called each time the
+// session's access token is refreshed.
+func (session *Session) OnRefresh(hook RefreshHook) {
+	if hook == nil {
+		return
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.refreshHooks = append(session.refreshHooks, hook)
+}
+
+// Refresh will re-authenticate with Salesforce using the configuration's
+// credentials and replace the session's access token.
+func (session *Session) Refresh() error {
+	return session.RefreshWithContext(context.Background())
+}
+
+// RefreshWithContext will re-authenticate with Salesforce using the context for
+// the authentication request.
+func (session *Session) RefreshWithContext(ctx context.Context) error {
+	session.mutex.RLock()
+	stale := session.authorization()
+	session.mutex.RUnlock()
+	return session.refresh(ctx, stale)
+}
+
+func (session *Session) refreshable() bool {
+	return session.config.Credentials != nil
+}
+
+// refresh will only re-authenticate if the stale authorization is still the
+// current one.  This keeps concurrent callers from refreshing more than once.
+// The lock is not held during the authentication request, so the callers that
+// arrive while it is in flight wait on its result or their context.
+func (session *Session) refresh(ctx context.Context, stale string) error {
+	session.mutex.Lock()
+	if session.authorization() != stale {
+		session.mutex.Unlock()
+		return nil
+	}
+	if session.config.Credentials == nil {
+		session.mutex.Unlock()
+		return errors.New("session: refresh requires the configuration credentials")
+	}
+	if call := session.refreshing; call != nil {
+		session.mutex.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &refreshCall{
+		done: make(chan struct{}),
+	}
+	session.refreshing = call
+	creds := session.config.Credentials
+	client := session.config.Client
+	session.mutex.Unlock()
+
+	response, err := authenticate(ctx, creds, client)
+
+	session.mutex.Lock()
+	var hooks []RefreshHook
+	if err == nil {
+		session.response = response
+		hooks = make([]RefreshHook, len(session.refreshHooks))
+		copy(hooks, session.refreshHooks)
+	}
+	call.err = err
+	session.refreshing = nil
+	session.mutex.Unlock()
+	close(call.done)
+
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		hook(session)
+	}
+	return nil
 }
@@ -1,8 +1,8 @@
 package session
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -50,7 +50,7 @@ func TestPasswordSessionRequest(t *testing.T) {
 		if err != nil {
 			t.Fatal("password credentials can not return an error for these tests")
 		}
-		request, err := passwordSessionRequest(passwordCreds)
+		request, err := passwordSessionRequest(context.Background(), passwordCreds)
 
 		if err != nil && scenario.err == nil {
 			t.Errorf("%s Error was not expected %s", scenario.desc, err.Error())
@@ -143,7 +143,7 @@ func TestPasswordSessionResponse(t *testing.T) {
 				}
 			}),
 			response: &sessionPasswordResponse{},
-			err:      fmt.Errorf("session response error: %d %s", http.StatusInternalServerError, "Some status"),
+			err:      &sfdc.APIError{StatusCode: http.StatusInternalServerError, Status: "Some status"},
 		},
 		{
 			desc: "Response Decode Error",
@@ -319,7 +319,7 @@ func TestNewPasswordSession(t *testing.T) {
 				Version: 45,
 			},
 			session: nil,
-			err:     fmt.Errorf("session response error: %d %s", http.StatusInternalServerError, "Some status"),
+			err:     &sfdc.APIError{StatusCode: http.StatusInternalServerError, Status: "Some status"},
 		},
 	}
 
@@ -454,6 +454,7 @@ func TestSession_Client(t *testing.T) {
 	type fields struct {
 		response *sessionPasswordResponse
 		config   sfdc.Configuration
+		client   *http.Client
 	}
 	tests := []struct {
 		name   string
@@ -467,6 +468,7 @@ func TestSession_Client(t *testing.T) {
 				config: sfdc.Configuration{
 					Client: http.DefaultClient,
 				},
+				client: http.DefaultClient,
 			},
 			want: http.DefaultClient,
 		},
@@ -476,6 +478,7 @@ func TestSession_Client(t *testing.T) {
 			session := &Session{
 				response: tt.fields.response,
 				config:   tt.fields.config,
+				client:   tt.fields.client,
 			}
 			if got := session.Client(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Session.Client() = %v, want %v", got, tt.want)
@@ -519,3 +522,63 @@ func TestSession_InstanceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestOpen_RefreshTokenCredentials(t *testing.T) {
+	var rotated string
+	creds, err := credentials.NewRefreshTokenCredentials(credentials.RefreshTokenCredentials{
+		URL:          "http://test.refresh.session",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+		RefreshToken: "old refresh token",
+		OnRotate: func(refreshToken string) error {
+			rotated = refreshToken
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	config := sfdc.Configuration{
+		Credentials: creds,
+		Client: mockHTTPClient(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			if strings.Contains(string(body), "grant_type=refresh_token") == false ||
+				strings.Contains(string(body), "refresh_token=old+refresh+token") == false {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "Bad Request",
+					Body:       ioutil.NopCloser(strings.NewReader(string(body))),
+					Header:     make(http.Header),
+				}
+			}
+			resp := `
+			{
+				"access_token": "token",
+				"refresh_token": "new refresh token",
+				"instance_url": "https://some.salesforce.instance.com",
+				"id": "https://test.salesforce.com/id/123456789",
+				"token_type": "Bearer",
+				"issued_at": "1553568410028",
+				"signature": "hello"
+			}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}
+		}),
+		Version: 45,
+	}
+
+	session, err := Open(config)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if session.response.AccessToken != "token" {
+		t.Errorf("Open() access token = %s, want token", session.response.AccessToken)
+	}
+	if rotated != "new refresh token" {
+		t.Errorf("Open() rotated refresh token = %s, want new refresh token", rotated)
+	}
+}
@@ -0,0 +1,111 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+const (
+	defaultTokenType = "Bearer"
+	userInfoEndpoint = "/services/oauth2/userinfo"
+)
+
+// Token is an existing access token that the session will be created from.
+//
+// AccessToken is the Salesforce access token.
+//
+// TokenType is the type of the token.  If empty, Bearer will be used.
+//
+// InstanceURL is the Salesforce instance of the access token.
+//
+// ID is the identity URL of the access token.  This field is optional.
+//
+// Validate will check the access token against the identity URL before the
+// session is returned.  If the ID is empty, the instance's user info
+// endpoint will be used.
+type Token struct {
+	AccessToken string
+	TokenType   string
+	InstanceURL string
+	ID          string
+	Validate    bool
+}
+
+// FromToken is used to create a session from an existing access token.  The
+// configuration's credentials are optional, and if present they will be used
+// to refresh the session.  Without the credentials, the session is not able
+// to refresh and unauthorized responses will be returned to the caller.
+func FromToken(config sfdc.Configuration, token Token) (*Session, error) {
+	return FromTokenWithContext(context.Background(), config, token)
+}
+
+// FromTokenWithContext is used to create a session from an existing access token
+// using the context for the validation request.
+func FromTokenWithContext(ctx context.Context, config sfdc.Configuration, token Token) (*Session, error) {
+	if token.AccessToken == "" {
+		return nil, errors.New("session: token access token can not be empty")
+	}
+	if token.InstanceURL == "" {
+		return nil, errors.New("session: token instance URL can not be empty")
+	}
+	if config.Client == nil {
+		return nil, errors.New("session: configuration client can not be nil")
+	}
+	if config.Version <= 0 && config.VersionPolicy != sfdc.VersionLatest {
+		return nil, errors.New("session: configuration version can not be less than zero")
+	}
+	if token.TokenType == "" {
+		token.TokenType = defaultTokenType
+	}
+
+	session := &Session{
+		response: &sessionPasswordResponse{
+			AccessToken: token.AccessToken,
+			InstanceURL: token.InstanceURL,
+			ID:          token.ID,
+			TokenType:   token.TokenType,
+		},
+		config: config,
+	}
+
+	if token.Validate {
+		if err := session.validate(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	session.client = session.newClient()
+
+	if err := session.negotiate(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// validate will request the identity of the access token.  The configuration's
+// client is used so that an invalid token is not refreshed.
+func (session *Session) validate(ctx context.Context) error {
+	identityURL := session.response.ID
+	if identityURL == "" {
+		identityURL = session.response.InstanceURL + userInfoEndpoint
+	}
+
+	request, err := session.identityRequest(ctx, identityURL)
+	if err != nil {
+		return err
+	}
+
+	response, err := session.config.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(response)
+
+	if response.StatusCode != http.StatusOK {
+		return sfdc.NewAPIError(response)
+	}
+	return nil
+}
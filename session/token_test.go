@@ -0,0 +1,246 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+)
+
+func mockIdentityClient(wantURL string) *http.Client {
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.String() != wantURL || req.Header.Get("Authorization") != "Bearer token" {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Status:     "Bad_OAuth_Token",
+				Body:       ioutil.NopCloser(strings.NewReader("Bad_OAuth_Token")),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "Some Status",
+			Body:       ioutil.NopCloser(strings.NewReader(`{"user_id":"123456789"}`)),
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func TestFromToken(t *testing.T) {
+	type args struct {
+		config sfdc.Configuration
+		token  Token
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantServiceURL string
+		wantErr        bool
+	}{
+		{
+			name: "Passing",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  http.DefaultClient,
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "token",
+					InstanceURL: "https://some.salesforce.instance.com",
+				},
+			},
+			wantServiceURL: "https://some.salesforce.instance.com/services/data/v45.0",
+			wantErr:        false,
+		},
+		{
+			name: "Validate Identity URL",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  mockIdentityClient("https://login.salesforce.com/id/00D/005"),
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "token",
+					TokenType:   "Bearer",
+					InstanceURL: "https://some.salesforce.instance.com",
+					ID:          "https://login.salesforce.com/id/00D/005",
+					Validate:    true,
+				},
+			},
+			wantServiceURL: "https://some.salesforce.instance.com/services/data/v45.0",
+			wantErr:        false,
+		},
+		{
+			name: "Validate User Info",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  mockIdentityClient("https://some.salesforce.instance.com" + userInfoEndpoint),
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "token",
+					InstanceURL: "https://some.salesforce.instance.com",
+					Validate:    true,
+				},
+			},
+			wantServiceURL: "https://some.salesforce.instance.com/services/data/v45.0",
+			wantErr:        false,
+		},
+		{
+			name: "Validate Invalid Token",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  mockIdentityClient("https://some.salesforce.instance.com" + userInfoEndpoint),
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "expired",
+					InstanceURL: "https://some.salesforce.instance.com",
+					Validate:    true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Access Token",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  http.DefaultClient,
+					Version: 45,
+				},
+				token: Token{
+					InstanceURL: "https://some.salesforce.instance.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Instance URL",
+			args: args{
+				config: sfdc.Configuration{
+					Client:  http.DefaultClient,
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "token",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Client",
+			args: args{
+				config: sfdc.Configuration{
+					Version: 45,
+				},
+				token: Token{
+					AccessToken: "token",
+					InstanceURL: "https://some.salesforce.instance.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Version",
+			args: args{
+				config: sfdc.Configuration{
+					Client: http.DefaultClient,
+				},
+				token: Token{
+					AccessToken: "token",
+					InstanceURL: "https://some.salesforce.instance.com",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromTokenWithContext(context.Background(), tt.args.config, tt.args.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ServiceURL() != tt.wantServiceURL {
+				t.Errorf("FromToken() service URL = %s, want %s", got.ServiceURL(), tt.wantServiceURL)
+			}
+			request, _ := http.NewRequest(http.MethodGet, got.ServiceURL(), nil)
+			got.AuthorizationHeader(request)
+			if auth := request.Header.Get("Authorization"); auth != "Bearer "+tt.args.token.AccessToken {
+				t.Errorf("FromToken() authorization = %s", auth)
+			}
+		})
+	}
+}
+
+func TestFromToken_Refresh(t *testing.T) {
+	var tokens int32
+	client := mockRefreshClient(&tokens, http.StatusOK)
+
+	t.Run("Not Refreshable", func(t *testing.T) {
+		session, err := FromToken(sfdc.Configuration{
+			Client:  client,
+			Version: 45,
+		}, Token{
+			AccessToken: "old",
+			InstanceURL: "https://some.salesforce.instance.com",
+		})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		request, _ := http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+		session.AuthorizationHeader(request)
+		response, err := session.Client().Do(request)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Session.Client().Do() status = %d, want %d", response.StatusCode, http.StatusUnauthorized)
+		}
+		if atomic.LoadInt32(&tokens) != 0 {
+			t.Errorf("Session.Client().Do() token requests = %d, want 0", tokens)
+		}
+	})
+
+	t.Run("Refreshable", func(t *testing.T) {
+		session, err := FromToken(sfdc.Configuration{
+			Credentials: testNewPasswordCredentials(credentials.PasswordCredentials{
+				URL:          "http://test.password.session",
+				Username:     "myusername",
+				Password:     "12345",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+			}),
+			Client:  client,
+			Version: 45,
+		}, Token{
+			AccessToken: "old",
+			InstanceURL: "https://some.salesforce.instance.com",
+		})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		request, _ := http.NewRequest(http.MethodGet, session.ServiceURL()+"/sobjects/Account", nil)
+		session.AuthorizationHeader(request)
+		response, err := session.Client().Do(request)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Session.Client().Do() status = %d, want %d", response.StatusCode, http.StatusOK)
+		}
+		if atomic.LoadInt32(&tokens) != 1 {
+			t.Errorf("Session.Client().Do() token requests = %d, want 1", tokens)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package session
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const limitInfoHeader = "Sforce-Limit-Info"
+
+// usageStale is how long the stored usage is kept over a lower used count.
+// The responses of concurrent requests are able to arrive out of order, but
+// the used count also drops as the 24 hour window moves.
+const usageStale = time.Minute
+
+// APIUsage is the org's API usage returned in the Sforce-Limit-Info header.
+//
+// Used is the number of API calls used in the last 24 hours.
+//
+// Limit is the org's API call limit for 24 hours.
+//
+// Updated is when the usage was returned.
+type APIUsage struct {
+	Used    int
+	Limit   int
+	Updated time.Time
+}
+
+// UsageHook is called when the API usage crosses a threshold.
+type UsageHook func(usage APIUsage)
+
+type usageThreshold struct {
+	percent float64
+	hook    UsageHook
+	crossed bool
+}
+
+type usageTransport struct {
+	session   *Session
+	transport http.RoundTripper
+}
+
+// Percent returns the percentage of the limit that has been used.
+func (usage APIUsage) Percent() float64 {
+	if usage.Limit <= 0 {
+		return 0
+	}
+	return float64(usage.Used) / float64(usage.Limit) * 100
+}
+
+// APIUsage returns the latest API usage from the Salesforce responses.  A
+// response with a lower used count than the stored usage is taken to be
+// older, unless the stored usage is more than a minute old.  If no response
+// has returned the usage, the zero value is returned.
+func (session *Session) APIUsage() APIUsage {
+	session.usageMutex.Lock()
+	defer session.usageMutex.Unlock()
+	return session.usage
+}
+
+// OnUsageThreshold will register a hook that is called when the API usage
+// reaches the percentage of the limit, like 80.  The hook is called once
+// each time the usage crosses the threshold.
+func (session *Session) OnUsageThreshold(percent float64, hook UsageHook) {
+	if hook == nil {
+		return
+	}
+	session.usageMutex.Lock()
+	defer session.usageMutex.Unlock()
+	session.thresholds = append(session.thresholds, &usageThreshold{
+		percent: percent,
+		hook:    hook,
+	})
+}
+
+// newer returns true if the usage should replace the stored usage.  The usage
+// is newer when the used count is not lower, the limit has changed or the
+// stored usage is stale.
+func (usage APIUsage) newer(stored APIUsage) bool {
+	switch {
+	case stored.Updated.IsZero(), usage.Used >= stored.Used, usage.Limit != stored.Limit:
+		return true
+	}
+	return usage.Updated.Sub(stored.Updated) > usageStale
+}
+
+func (session *Session) updateUsage(usage APIUsage) {
+	session.usageMutex.Lock()
+	if usage.newer(session.usage) == false {
+		session.usageMutex.Unlock()
+		return
+	}
+	session.usage = usage
+	var hooks []UsageHook
+	for _, threshold := range session.thresholds {
+		reached := usage.Percent() >= threshold.percent
+		if reached && threshold.crossed == false {
+			hooks = append(hooks, threshold.hook)
+		}
+		threshold.crossed = reached
+	}
+	session.usageMutex.Unlock()
+
+	for _, hook := range hooks {
+		hook(usage)
+	}
+}
+
+func (t *usageTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+	if usage, has := parseLimitInfo(response.Header.Get(limitInfoHeader)); has {
+		usage.Updated = time.Now()
+		t.session.updateUsage(usage)
+	}
+	return response, nil
+}
+
+// parseLimitInfo will parse the api-usage from the header, for example
+// api-usage=18/5000, per-app-api-usage=17/250(appName=sample-app).
+func parseLimitInfo(header string) (APIUsage, bool) {
+	for _, info := range strings.Split(header, ",") {
+		info = strings.TrimSpace(info)
+		if strings.HasPrefix(info, "api-usage=") == false {
+			continue
+		}
+		values := strings.SplitN(strings.TrimPrefix(info, "api-usage="), "/", 2)
+		if len(values) != 2 {
+			return APIUsage{}, false
+		}
+		used, err := strconv.Atoi(values[0])
+		if err != nil {
+			return APIUsage{}, false
+		}
+		limit, err := strconv.Atoi(values[1])
+		if err != nil {
+			return APIUsage{}, false
+		}
+		return APIUsage{
+			Used:  used,
+			Limit: limit,
+		}, true
+	}
+	return APIUsage{}, false
+}
@@ -0,0 +1,171 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func Test_parseLimitInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    APIUsage
+		wantHas bool
+	}{
+		{
+			name:    "API Usage",
+			header:  "api-usage=18/5000",
+			want:    APIUsage{Used: 18, Limit: 5000},
+			wantHas: true,
+		},
+		{
+			name:    "Per App Usage",
+			header:  "per-app-api-usage=17/250(appName=sample-app), api-usage=25/5000",
+			want:    APIUsage{Used: 25, Limit: 5000},
+			wantHas: true,
+		},
+		{
+			name:    "Not Present",
+			header:  "",
+			wantHas: false,
+		},
+		{
+			name:    "Not A Number",
+			header:  "api-usage=lots/5000",
+			wantHas: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, has := parseLimitInfo(tt.header)
+			if has != tt.wantHas {
+				t.Errorf("parseLimitInfo() has = %t, want %t", has, tt.wantHas)
+			}
+			if got != tt.want {
+				t.Errorf("parseLimitInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIUsage_Percent(t *testing.T) {
+	if got := (APIUsage{Used: 4000, Limit: 5000}).Percent(); got != 80 {
+		t.Errorf("APIUsage.Percent() = %v, want 80", got)
+	}
+	if got := (APIUsage{}).Percent(); got != 0 {
+		t.Errorf("APIUsage.Percent() = %v, want 0", got)
+	}
+}
+
+func TestSession_OnUsageThreshold(t *testing.T) {
+	usages := []string{"api-usage=100/1000", "api-usage=850/1000", "api-usage=900/1000", "api-usage=10/1000", "api-usage=810/1000", ""}
+	call := 0
+	session := &Session{
+		response: &sessionPasswordResponse{
+			AccessToken: "token",
+			InstanceURL: "https://some.salesforce.instance.com",
+			TokenType:   "Bearer",
+		},
+		config: sfdc.Configuration{
+			Client: mockHTTPClient(func(req *http.Request) *http.Response {
+				header := make(http.Header)
+				if usages[call] != "" {
+					header.Set(limitInfoHeader, usages[call])
+				}
+				call++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Header:     header,
+				}
+			}),
+			Version: 45,
+		},
+	}
+	session.client = session.newClient()
+
+	var crossed []int
+	session.OnUsageThreshold(80, func(usage APIUsage) {
+		crossed = append(crossed, usage.Used)
+	})
+
+	for _, header := range usages {
+		if header == "api-usage=10/1000" {
+			session.usageMutex.Lock()
+			session.usage.Updated = session.usage.Updated.Add(-2 * usageStale)
+			session.usageMutex.Unlock()
+		}
+		request, _ := http.NewRequest(http.MethodGet, session.ServiceURL()+"/limits", nil)
+		session.AuthorizationHeader(request)
+		response, err := session.Client().Do(request)
+		if err != nil {
+			t.Fatalf("Session.Client().Do() error = %v", err)
+		}
+		response.Body.Close()
+	}
+
+	if len(crossed) != 2 || crossed[0] != 850 || crossed[1] != 810 {
+		t.Errorf("Session.OnUsageThreshold() crossed = %v, want [850 810]", crossed)
+	}
+	usage := session.APIUsage()
+	if usage.Used != 810 || usage.Limit != 1000 || usage.Updated.IsZero() {
+		t.Errorf("Session.APIUsage() = %v", usage)
+	}
+}
+
+func TestSession_updateUsage(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		stored APIUsage
+		usage  APIUsage
+		want   int
+	}{
+		{
+			name:   "First",
+			stored: APIUsage{},
+			usage:  APIUsage{Used: 10, Limit: 1000, Updated: now},
+			want:   10,
+		},
+		{
+			name:   "Higher",
+			stored: APIUsage{Used: 10, Limit: 1000, Updated: now},
+			usage:  APIUsage{Used: 11, Limit: 1000, Updated: now},
+			want:   11,
+		},
+		{
+			name:   "Out Of Order",
+			stored: APIUsage{Used: 11, Limit: 1000, Updated: now},
+			usage:  APIUsage{Used: 10, Limit: 1000, Updated: now.Add(time.Millisecond)},
+			want:   11,
+		},
+		{
+			name:   "Limit Changed",
+			stored: APIUsage{Used: 11, Limit: 1000, Updated: now},
+			usage:  APIUsage{Used: 10, Limit: 2000, Updated: now},
+			want:   10,
+		},
+		{
+			name:   "Stale",
+			stored: APIUsage{Used: 900, Limit: 1000, Updated: now.Add(-2 * usageStale)},
+			usage:  APIUsage{Used: 10, Limit: 1000, Updated: now},
+			want:   10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{
+				usage: tt.stored,
+			}
+			session.updateUsage(tt.usage)
+			if got := session.APIUsage().Used; got != tt.want {
+				t.Errorf("Session.updateUsage() used = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
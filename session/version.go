@@ -0,0 +1,128 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+const versionsEndpoint = "/services/data/"
+
+// Version is a Salesforce API version that the instance supports.
+//
+// Label is the release name, like Spring '19.
+//
+// URL is the resource path of the version.
+//
+// Version is the API version, like 45.0.
+type Version struct {
+	Label   string `json:"label"`
+	URL     string `json:"url"`
+	Version string `json:"version"`
+}
+
+// Number returns the major version number, like 45.  If the version can not
+// be parsed, zero is returned.
+func (version Version) Number() int {
+	number, err := strconv.ParseFloat(version.Version, 64)
+	if err != nil {
+		return 0
+	}
+	return int(number)
+}
+
+// Versions returns the API versions that the instance supports.
+func (session *Session) Versions() ([]Version, error) {
+	return session.VersionsWithContext(context.Background())
+}
+
+// VersionsWithContext returns the API versions that the instance supports using the
+// context for the request.
+func (session *Session) VersionsWithContext(ctx context.Context) ([]Version, error) {
+	var versions []Version
+	if err := session.get(ctx, session.InstanceURL()+versionsEndpoint, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// Resources returns the resources that the session's API version offers,
+// keyed by the resource name with the resource path as the value.
+func (session *Session) Resources() (map[string]string, error) {
+	return session.ResourcesWithContext(context.Background())
+}
+
+// ResourcesWithContext returns the resources that the session's API version offers using
+// the context for the request.
+func (session *Session) ResourcesWithContext(ctx context.Context) (map[string]string, error) {
+	var resources map[string]string
+	if err := session.get(ctx, session.ServiceURL()+"/", &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (session *Session) get(ctx context.Context, url string, value interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Accept", "application/json")
+	session.AuthorizationHeader(request)
+
+	response, err := session.Client().Do(request)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(response)
+
+	if response.StatusCode != http.StatusOK {
+		return sfdc.NewAPIError(response)
+	}
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+// negotiate will apply the configuration's version policy.
+func (session *Session) negotiate(ctx context.Context) error {
+	if session.config.VersionPolicy == sfdc.VersionAsConfigured {
+		return nil
+	}
+
+	versions, err := session.VersionsWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	supported := make([]string, len(versions))
+	latest := 0
+	for idx, version := range versions {
+		number := version.Number()
+		if session.config.VersionPolicy == sfdc.VersionVerify && number == session.config.Version {
+			return nil
+		}
+		if number > latest {
+			latest = number
+		}
+		supported[idx] = version.Version
+	}
+
+	switch session.config.VersionPolicy {
+	case sfdc.VersionLatest:
+		if latest == 0 {
+			return errors.New("session: the instance did not return any API versions")
+		}
+		session.mutex.Lock()
+		session.config.Version = latest
+		session.mutex.Unlock()
+		return nil
+	case sfdc.VersionVerify:
+		return fmt.Errorf("session: version %d is not supported by the instance, the supported versions are %s", session.config.Version, strings.Join(supported, ", "))
+	}
+	return fmt.Errorf("session: %d is not a valid version policy", session.config.VersionPolicy)
+}
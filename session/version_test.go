@@ -0,0 +1,206 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+)
+
+func mockVersionClient() *http.Client {
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		var resp string
+		switch req.URL.String() {
+		case "http://test.password.session" + oauthEndpoint:
+			resp = `
+			{
+				"access_token": "token",
+				"instance_url": "https://some.salesforce.instance.com",
+				"id": "https://test.salesforce.com/id/123456789",
+				"token_type": "Bearer",
+				"issued_at": "1553568410028",
+				"signature": "hello"
+			}`
+		case "https://some.salesforce.instance.com" + versionsEndpoint:
+			resp = `
+			[
+				{
+					"label" : "Spring '19",
+					"url" : "/services/data/v45.0",
+					"version" : "45.0"
+				},
+				{
+					"label" : "Summer '19",
+					"url" : "/services/data/v46.0",
+					"version" : "46.0"
+				}
+			]`
+		case "https://some.salesforce.instance.com/services/data/v46.0/":
+			resp = `
+			{
+				"limits" : "/services/data/v46.0/limits",
+				"sobjects" : "/services/data/v46.0/sobjects"
+			}`
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "Not Found",
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`)),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "Some Status",
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func TestSession_Versions(t *testing.T) {
+	session := testIdentitySession(&sessionPasswordResponse{
+		AccessToken: "token",
+		InstanceURL: "https://some.salesforce.instance.com",
+		TokenType:   "Bearer",
+	}, mockVersionClient())
+
+	got, err := session.VersionsWithContext(context.Background())
+	if err != nil {
+		t.Fatalf("Session.Versions() error = %v", err)
+	}
+	want := []Version{
+		{
+			Label:   "Spring '19",
+			URL:     "/services/data/v45.0",
+			Version: "45.0",
+		},
+		{
+			Label:   "Summer '19",
+			URL:     "/services/data/v46.0",
+			Version: "46.0",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Session.Versions() = %v, want %v", got, want)
+	}
+}
+
+func TestSession_Resources(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "Passing",
+			version: 46,
+			want: map[string]string{
+				"limits":   "/services/data/v46.0/limits",
+				"sobjects": "/services/data/v46.0/sobjects",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Not Found",
+			version: 20,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := testIdentitySession(&sessionPasswordResponse{
+				AccessToken: "token",
+				InstanceURL: "https://some.salesforce.instance.com",
+				TokenType:   "Bearer",
+			}, mockVersionClient())
+			session.config.Version = tt.version
+
+			got, err := session.ResourcesWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Session.Resources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Session.Resources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpen_VersionPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		policy      sfdc.VersionPolicy
+		wantVersion int
+		wantErr     bool
+	}{
+		{
+			name:        "As Configured",
+			version:     20,
+			policy:      sfdc.VersionAsConfigured,
+			wantVersion: 20,
+			wantErr:     false,
+		},
+		{
+			name:        "Latest",
+			version:     0,
+			policy:      sfdc.VersionLatest,
+			wantVersion: 46,
+			wantErr:     false,
+		},
+		{
+			name:        "Verify",
+			version:     45,
+			policy:      sfdc.VersionVerify,
+			wantVersion: 45,
+			wantErr:     false,
+		},
+		{
+			name:    "Verify Not Supported",
+			version: 50,
+			policy:  sfdc.VersionVerify,
+			wantErr: true,
+		},
+		{
+			name:    "No Version",
+			version: 0,
+			policy:  sfdc.VersionVerify,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := Open(sfdc.Configuration{
+				Credentials: testNewPasswordCredentials(credentials.PasswordCredentials{
+					URL:          "http://test.password.session",
+					Username:     "myusername",
+					Password:     "12345",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+				}),
+				Client:        mockVersionClient(),
+				Version:       tt.version,
+				VersionPolicy: tt.policy,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Open() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if session.config.Version != tt.wantVersion {
+				t.Errorf("Open() version = %d, want %d", session.config.Version, tt.wantVersion)
+			}
+		})
+	}
+}
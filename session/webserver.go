@@ -0,0 +1,294 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+)
+
+const (
+	authorizeEndpoint   = "/services/oauth2/authorize"
+	authorizationCode   = "authorization_code"
+	codeChallengeMethod = "S256"
+	pendingExpiration   = 10 * time.Minute
+)
+
+// WebServerConfig is the configuration for the OAuth web server flow.
+//
+// URL is the login URL used, examples would be https://test.salesforce.com or https://login.salesforce.com
+//
+// ClientID is the client ID from the connected application.
+//
+// ClientSecret is the client secret from the connected application.  This is
+// optional when the connected application does not require the secret.
+//
+// RedirectURL is the callback URL of the connected application.
+//
+// Scopes are the OAuth scopes requested.  This field is optional.
+//
+// Client is the HTTP client that will be used.
+//
+// Version is the Salesforce version for the APIs.
+//
+// VersionPolicy is how the version is checked when the code is exchanged.
+// The default is to use the version as configured.
+//
+// RetryPolicy is the session's policy for retrying transient failures.  If
+// nil, requests are not retried.
+//
+// Middleware is the chain that each of the session's API requests and
+// responses flows through.  This field is optional.
+//
+// OnRotate is called when Salesforce issues a new refresh token as a session
+// refreshes, after the refresh token from the exchange.  This allows the new
+// refresh token to be persisted.  This field is optional.
+type WebServerConfig struct {
+	URL           string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	Client        *http.Client
+	Version       int
+	VersionPolicy sfdc.VersionPolicy
+	RetryPolicy   *sfdc.RetryPolicy
+	Middleware    []sfdc.Middleware
+	OnRotate      func(refreshToken string) error
+}
+
+// Authorization is the information needed to send the user to the
+// Salesforce authorize endpoint.
+//
+// URL is the authorize URL that the user is redirected to.
+//
+// State is the value that will be returned to the callback.
+//
+// Verifier is the PKCE code verifier that is needed to exchange the code.
+type Authorization struct {
+	URL      string
+	State    string
+	Verifier string
+}
+
+// WebServerCallback is called by the web server flow handler after the
+// authorization code has been exchanged.  If the exchange has failed, the
+// error will be returned.
+type WebServerCallback func(w http.ResponseWriter, r *http.Request, session *Session, refreshToken string, err error)
+
+// WebServerFlow is the OAuth web server (authorization code) flow with
+// a PKCE code challenge.
+type WebServerFlow struct {
+	config  WebServerConfig
+	mutex   sync.Mutex
+	pending map[string]pendingAuthorization
+}
+
+type pendingAuthorization struct {
+	verifier string
+	created  time.Time
+}
+
+type authorizationCodeProvider struct {
+	url  string
+	form url.Values
+}
+
+func (provider *authorizationCodeProvider) Retrieve() (io.Reader, error) {
+	return strings.NewReader(provider.form.Encode()), nil
+}
+
+func (provider *authorizationCodeProvider) URL() string {
+	return provider.url
+}
+
+// NewWebServerFlow creates the web server flow from the configuration.
+func NewWebServerFlow(config WebServerConfig) (*WebServerFlow, error) {
+	switch {
+	case config.URL == "":
+		return nil, errors.New("session web server: configuration URL can not be empty")
+	case config.ClientID == "":
+		return nil, errors.New("session web server: configuration client ID can not be empty")
+	case config.RedirectURL == "":
+		return nil, errors.New("session web server: configuration redirect URL can not be empty")
+	case config.Client == nil:
+		return nil, errors.New("session web server: configuration client can not be nil")
+	case config.Version <= 0 && config.VersionPolicy != sfdc.VersionLatest:
+		return nil, errors.New("session web server: configuration version can not be less than zero")
+	}
+	return &WebServerFlow{
+		config:  config,
+		pending: make(map[string]pendingAuthorization),
+	}, nil
+}
+
+// Authorize will create a new state and PKCE code verifier and return the
+// authorize URL.  The state is kept so that the handler is able to exchange
+// the code when the user is returned to the callback.
+func (flow *WebServerFlow) Authorize() (Authorization, error) {
+	state, err := randomValue()
+	if err != nil {
+		return Authorization{}, err
+	}
+	verifier, err := randomValue()
+	if err != nil {
+		return Authorization{}, err
+	}
+
+	flow.mutex.Lock()
+	defer flow.mutex.Unlock()
+	now := time.Now()
+	for key, value := range flow.pending {
+		if now.Sub(value.created) > pendingExpiration {
+			delete(flow.pending, key)
+		}
+	}
+	flow.pending[state] = pendingAuthorization{
+		verifier: verifier,
+		created:  now,
+	}
+
+	return Authorization{
+		URL:      flow.AuthorizeURL(state, verifier),
+		State:    state,
+		Verifier: verifier,
+	}, nil
+}
+
+// AuthorizeURL will return the authorize URL with the state and the code
+// challenge of the verifier.
+func (flow *WebServerFlow) AuthorizeURL(state, verifier string) string {
+	form := url.Values{}
+	form.Add("response_type", "code")
+	form.Add("client_id", flow.config.ClientID)
+	form.Add("redirect_uri", flow.config.RedirectURL)
+	form.Add("state", state)
+	form.Add("code_challenge", codeChallenge(verifier))
+	form.Add("code_challenge_method", codeChallengeMethod)
+	if len(flow.config.Scopes) > 0 {
+		form.Add("scope", strings.Join(flow.config.Scopes, " "))
+	}
+	return flow.config.URL + authorizeEndpoint + "?" + form.Encode()
+}
+
+// Exchange will exchange the authorization code for a session.  If Salesforce
+// issues a refresh token, it is returned and the session will be able to
+// refresh with it.  The session uses the configuration's version policy, retry
+// policy and middleware.
+func (flow *WebServerFlow) Exchange(ctx context.Context, code, verifier string) (*Session, string, error) {
+	if code == "" {
+		return nil, "", errors.New("session web server: code can not be empty")
+	}
+
+	form := url.Values{}
+	form.Add("grant_type", authorizationCode)
+	form.Add("code", code)
+	form.Add("client_id", flow.config.ClientID)
+	if flow.config.ClientSecret != "" {
+		form.Add("client_secret", flow.config.ClientSecret)
+	}
+	form.Add("redirect_uri", flow.config.RedirectURL)
+	if verifier != "" {
+		form.Add("code_verifier", verifier)
+	}
+	creds, err := credentials.NewCredentials(&authorizationCodeProvider{
+		url:  flow.config.URL,
+		form: form,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	response, err := authenticate(ctx, creds, flow.config.Client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	config := sfdc.Configuration{
+		Client:        flow.config.Client,
+		Version:       flow.config.Version,
+		VersionPolicy: flow.config.VersionPolicy,
+		RetryPolicy:   flow.config.RetryPolicy,
+		Middleware:    flow.config.Middleware,
+	}
+	if response.RefreshToken != "" {
+		config.Credentials, err = credentials.NewRefreshTokenCredentials(credentials.RefreshTokenCredentials{
+			URL:          flow.config.URL,
+			ClientID:     flow.config.ClientID,
+			ClientSecret: flow.config.ClientSecret,
+			RefreshToken: response.RefreshToken,
+			OnRotate:     flow.config.OnRotate,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	session := &Session{
+		response: response,
+		config:   config,
+	}
+	session.client = session.newClient()
+
+	if err := session.negotiate(ctx); err != nil {
+		return nil, "", err
+	}
+	return session, response.RefreshToken, nil
+}
+
+// Handler returns the HTTP handler for the redirect URL callback.  The handler
+// will validate the state, exchange the code and pass the result to the callback.
+// If the callback is nil, the handler will answer each request with an
+// internal server error.
+func (flow *WebServerFlow) Handler(callback WebServerCallback) http.Handler {
+	if callback == nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "session web server: callback can not be nil", http.StatusInternalServerError)
+		})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if oauthErr := query.Get("error"); oauthErr != "" {
+			callback(w, r, nil, "", fmt.Errorf("session web server: %s %s", oauthErr, query.Get("error_description")))
+			return
+		}
+
+		state := query.Get("state")
+		flow.mutex.Lock()
+		pending, has := flow.pending[state]
+		delete(flow.pending, state)
+		flow.mutex.Unlock()
+
+		if has == false || time.Since(pending.created) > pendingExpiration {
+			callback(w, r, nil, "", errors.New("session web server: state is unknown or has expired"))
+			return
+		}
+
+		session, refreshToken, err := flow.Exchange(r.Context(), query.Get("code"), pending.verifier)
+		callback(w, r, session, refreshToken, err)
+	})
+}
+
+func randomValue() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+func codeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,443 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func mockTokenServer(t *testing.T, challenge *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != oauthEndpoint {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err.Error())
+		}
+		switch {
+		case r.PostForm.Get("grant_type") != authorizationCode,
+			r.PostForm.Get("code") != "good-code",
+			r.PostForm.Get("client_id") != "some client id",
+			r.PostForm.Get("redirect_uri") != "https://my.app/callback",
+			codeChallenge(r.PostForm.Get("code_verifier")) != *challenge:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_grant",
+				"error_description": "authentication failure",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token":  "token",
+			"refresh_token": "refresh",
+			"instance_url":  "https://some.salesforce.instance.com",
+			"id":            "https://test.salesforce.com/id/123456789",
+			"token_type":    "Bearer",
+			"issued_at":     "1553568410028",
+			"signature":     "hello",
+		})
+	}))
+}
+
+func TestNewWebServerFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  WebServerConfig
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			config: WebServerConfig{
+				URL:         "https://login.salesforce.com",
+				ClientID:    "some client id",
+				RedirectURL: "https://my.app/callback",
+				Client:      http.DefaultClient,
+				Version:     45,
+			},
+			wantErr: false,
+		},
+		{
+			name: "No URL",
+			config: WebServerConfig{
+				ClientID:    "some client id",
+				RedirectURL: "https://my.app/callback",
+				Client:      http.DefaultClient,
+				Version:     45,
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Client ID",
+			config: WebServerConfig{
+				URL:         "https://login.salesforce.com",
+				RedirectURL: "https://my.app/callback",
+				Client:      http.DefaultClient,
+				Version:     45,
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Redirect URL",
+			config: WebServerConfig{
+				URL:      "https://login.salesforce.com",
+				ClientID: "some client id",
+				Client:   http.DefaultClient,
+				Version:  45,
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Client",
+			config: WebServerConfig{
+				URL:         "https://login.salesforce.com",
+				ClientID:    "some client id",
+				RedirectURL: "https://my.app/callback",
+				Version:     45,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebServerFlow(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWebServerFlow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebServerFlow_Authorize(t *testing.T) {
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:         "https://login.salesforce.com",
+		ClientID:    "some client id",
+		RedirectURL: "https://my.app/callback",
+		Scopes:      []string{"api", "refresh_token"},
+		Client:      http.DefaultClient,
+		Version:     45,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	authorization, err := flow.Authorize()
+	if err != nil {
+		t.Fatalf("WebServerFlow.Authorize() error = %v", err)
+	}
+	authURL, err := url.Parse(authorization.URL)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if authURL.Path != authorizeEndpoint {
+		t.Errorf("WebServerFlow.Authorize() path = %s, want %s", authURL.Path, authorizeEndpoint)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "some client id",
+		"redirect_uri":          "https://my.app/callback",
+		"state":                 authorization.State,
+		"code_challenge":        codeChallenge(authorization.Verifier),
+		"code_challenge_method": "S256",
+		"scope":                 "api refresh_token",
+	}
+	for key, value := range want {
+		if got := authURL.Query().Get(key); got != value {
+			t.Errorf("WebServerFlow.Authorize() %s = %s, want %s", key, got, value)
+		}
+	}
+	if len(authorization.Verifier) < 43 {
+		t.Errorf("WebServerFlow.Authorize() verifier length %d is less than 43", len(authorization.Verifier))
+	}
+}
+
+func TestWebServerFlow_Handler(t *testing.T) {
+	var challenge string
+	server := mockTokenServer(t, &challenge)
+	defer server.Close()
+
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:         server.URL,
+		ClientID:    "some client id",
+		RedirectURL: "https://my.app/callback",
+		Client:      server.Client(),
+		Version:     45,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name      string
+		query     func(state string) string
+		wantToken string
+		wantErr   bool
+	}{
+		{
+			name: "Passing",
+			query: func(state string) string {
+				return "code=good-code&state=" + url.QueryEscape(state)
+			},
+			wantToken: "refresh",
+			wantErr:   false,
+		},
+		{
+			name: "Unknown State",
+			query: func(state string) string {
+				return "code=good-code&state=unknown"
+			},
+			wantErr: true,
+		},
+		{
+			name: "Bad Code",
+			query: func(state string) string {
+				return "code=bad-code&state=" + url.QueryEscape(state)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Access Denied",
+			query: func(state string) string {
+				return "error=access_denied&error_description=end-user+denied+authorization&state=" + url.QueryEscape(state)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization, err := flow.Authorize()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			authURL, _ := url.Parse(authorization.URL)
+			challenge = authURL.Query().Get("code_challenge")
+
+			var gotSession *Session
+			var gotToken string
+			var gotErr error
+			handler := flow.Handler(func(w http.ResponseWriter, r *http.Request, session *Session, refreshToken string, err error) {
+				gotSession, gotToken, gotErr = session, refreshToken, err
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "https://my.app/callback?"+tt.query(authorization.State), nil)
+			handler.ServeHTTP(recorder, request)
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Fatalf("WebServerFlow.Handler() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if gotErr != nil {
+				return
+			}
+			if gotToken != tt.wantToken {
+				t.Errorf("WebServerFlow.Handler() refresh token = %s, want %s", gotToken, tt.wantToken)
+			}
+			if gotSession.InstanceURL() != "https://some.salesforce.instance.com" {
+				t.Errorf("WebServerFlow.Handler() instance URL = %s", gotSession.InstanceURL())
+			}
+			if gotSession.refreshable() == false {
+				t.Error("WebServerFlow.Handler() session is not able to refresh")
+			}
+		})
+	}
+}
+
+func TestWebServerFlow_Exchange(t *testing.T) {
+	var challenge string
+	server := mockTokenServer(t, &challenge)
+	defer server.Close()
+
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:         server.URL,
+		ClientID:    "some client id",
+		RedirectURL: "https://my.app/callback",
+		Client:      server.Client(),
+		Version:     45,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	verifier := strings.Repeat("v", 43)
+	challenge = codeChallenge(verifier)
+
+	session, refreshToken, err := flow.Exchange(context.Background(), "good-code", verifier)
+	if err != nil {
+		t.Fatalf("WebServerFlow.Exchange() error = %v", err)
+	}
+	if refreshToken != "refresh" {
+		t.Errorf("WebServerFlow.Exchange() refresh token = %s, want refresh", refreshToken)
+	}
+	if session.ServiceURL() != "https://some.salesforce.instance.com/services/data/v45.0" {
+		t.Errorf("WebServerFlow.Exchange() service URL = %s", session.ServiceURL())
+	}
+
+	if _, _, err := flow.Exchange(context.Background(), "", verifier); err == nil {
+		t.Error("WebServerFlow.Exchange() expected an error for an empty code")
+	}
+}
+
+func TestWebServerFlow_Exchange_Configuration(t *testing.T) {
+	var versionCalls int32
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		switch req.URL.Path {
+		case oauthEndpoint:
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(`
+				{
+					"access_token": "token",
+					"instance_url": "https://some.salesforce.instance.com",
+					"id": "https://test.salesforce.com/id/123456789",
+					"token_type": "Bearer",
+					"issued_at": "1553568410028",
+					"signature": "hello"
+				}`)),
+				Header: make(http.Header),
+			}
+		case versionsEndpoint:
+			if atomic.AddInt32(&versionCalls, 1) == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Status:     "Service Unavailable",
+					Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"unavailable","errorCode":"SERVER_UNAVAILABLE"}]`)),
+					Header:     make(http.Header),
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"label":"Winter '20","url":"/services/data/v47.0","version":"47.0"}]`)),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "Not Found",
+			Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	var middlewareCalls int32
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:           "https://login.salesforce.com",
+		ClientID:      "some client id",
+		RedirectURL:   "https://my.app/callback",
+		Client:        client,
+		VersionPolicy: sfdc.VersionLatest,
+		RetryPolicy: &sfdc.RetryPolicy{
+			MaxAttempts: 2,
+			MinBackoff:  time.Millisecond,
+		},
+		Middleware: []sfdc.Middleware{
+			func(next http.RoundTripper) http.RoundTripper {
+				return sfdc.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&middlewareCalls, 1)
+					return next.RoundTrip(request)
+				})
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	session, _, err := flow.Exchange(context.Background(), "good-code", "")
+	if err != nil {
+		t.Fatalf("WebServerFlow.Exchange() error = %v", err)
+	}
+	if session.ServiceURL() != "https://some.salesforce.instance.com/services/data/v47.0" {
+		t.Errorf("WebServerFlow.Exchange() service URL = %s", session.ServiceURL())
+	}
+	if versionCalls != 2 {
+		t.Errorf("WebServerFlow.Exchange() version requests = %d, want 2", versionCalls)
+	}
+	if middlewareCalls != 1 {
+		t.Errorf("WebServerFlow.Exchange() middleware calls = %d, want 1", middlewareCalls)
+	}
+}
+
+func TestWebServerFlow_Handler_NilCallback(t *testing.T) {
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:         "https://login.salesforce.com",
+		ClientID:    "some client id",
+		RedirectURL: "https://my.app/callback",
+		Client:      http.DefaultClient,
+		Version:     45,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "https://my.app/callback?code=good-code&state=unknown", nil)
+	flow.Handler(nil).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("WebServerFlow.Handler() status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestWebServerFlow_Exchange_OnRotate(t *testing.T) {
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		body, _ := ioutil.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		refreshToken := "refresh"
+		if form.Get("grant_type") == "refresh_token" {
+			refreshToken = "rotated " + form.Get("refresh_token")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`
+			{
+				"access_token": "token",
+				"refresh_token": "` + refreshToken + `",
+				"instance_url": "https://some.salesforce.instance.com",
+				"id": "https://test.salesforce.com/id/123456789",
+				"token_type": "Bearer",
+				"issued_at": "1553568410028",
+				"signature": "hello"
+			}`)),
+			Header: make(http.Header),
+		}
+	})
+
+	var rotated []string
+	flow, err := NewWebServerFlow(WebServerConfig{
+		URL:         "https://login.salesforce.com",
+		ClientID:    "some client id",
+		RedirectURL: "https://my.app/callback",
+		Client:      client,
+		Version:     45,
+		OnRotate: func(refreshToken string) error {
+			rotated = append(rotated, refreshToken)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	session, refreshToken, err := flow.Exchange(context.Background(), "good-code", "")
+	if err != nil {
+		t.Fatalf("WebServerFlow.Exchange() error = %v", err)
+	}
+	if refreshToken != "refresh" {
+		t.Errorf("WebServerFlow.Exchange() refresh token = %s, want refresh", refreshToken)
+	}
+	if err := session.Refresh(); err != nil {
+		t.Fatalf("Session.Refresh() error = %v", err)
+	}
+	if len(rotated) != 1 || rotated[0] != "rotated refresh" {
+		t.Errorf("WebServerConfig.OnRotate() refresh tokens = %v, want [rotated refresh]", rotated)
+	}
+}
@@ -0,0 +1,319 @@
+package sfdctest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var columnDelimiters = map[string]rune{
+	"BACKQUOTE": '`',
+	"CARET":     '^',
+	"COMMA":     ',',
+	"PIPE":      '|',
+	"SEMICOLON": ';',
+	"TAB":       '\t',
+}
+
+var operations = map[string]struct{}{
+	"insert": {},
+	"update": {},
+	"upsert": {},
+	"delete": {},
+}
+
+// jobInfo is the Bulk 2.0 ingest job's response and information.
+type jobInfo struct {
+	APIVersion             float32 `json:"apiVersion"`
+	ColumnDelimiter        string  `json:"columnDelimiter"`
+	ConcurrencyMode        string  `json:"concurrencyMode"`
+	ContentType            string  `json:"contentType"`
+	ContentURL             string  `json:"contentUrl"`
+	CreatedByID            string  `json:"createdById"`
+	CreatedDate            string  `json:"createdDate"`
+	ExternalIDFieldName    string  `json:"externalIdFieldName"`
+	ID                     string  `json:"id"`
+	JobType                string  `json:"jobType"`
+	LineEnding             string  `json:"lineEnding"`
+	Object                 string  `json:"object"`
+	Operation              string  `json:"operation"`
+	State                  string  `json:"state"`
+	SystemModstamp         string  `json:"systemModstamp"`
+	NumberRecordsFailed    int     `json:"numberRecordsFailed"`
+	NumberRecordsProcessed int     `json:"numberRecordsProcessed"`
+	ErrorMessage           string  `json:"errorMessage,omitempty"`
+}
+
+type job struct {
+	info        jobInfo
+	data        bytes.Buffer
+	header      []string
+	successful  [][]string
+	failed      [][]string
+	unprocessed [][]string
+}
+
+func (s *Server) ingest(w http.ResponseWriter, r *http.Request, version int, resource string) {
+	if resource == "" {
+		switch r.Method {
+		case http.MethodPost:
+			s.createJob(w, r, version)
+		case http.MethodGet:
+			s.listJobs(w)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+		}
+		return
+	}
+
+	parts := strings.SplitN(resource, "/", 2)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	j, has := s.jobs[parts[0]]
+	if has == false {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, j.info)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		s.setJobState(w, r, j)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.jobs, j.info.ID)
+		for idx, id := range s.jobOrder {
+			if id == j.info.ID {
+				s.jobOrder = append(s.jobOrder[:idx], s.jobOrder[idx+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case parts[1] == "batches" && r.Method == http.MethodPut:
+		if j.info.State != "Open" {
+			writeError(w, http.StatusConflict, "INVALIDJOBSTATE", "Job is not open for data upload")
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALIDBATCH", err.Error())
+			return
+		}
+		j.data.Write(body)
+		w.WriteHeader(http.StatusCreated)
+	case parts[1] == "successfulResults" && r.Method == http.MethodGet:
+		j.writeResults(w, append([]string{"sf__Id", "sf__Created"}, j.header...), j.successful)
+	case parts[1] == "failedResults" && r.Method == http.MethodGet:
+		j.writeResults(w, append([]string{"sf__Id", "sf__Error"}, j.header...), j.failed)
+	case parts[1] == "unprocessedrecords" && r.Method == http.MethodGet:
+		j.writeResults(w, j.header, j.unprocessed)
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+	}
+}
+
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request, version int) {
+	var options jobInfo
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+		return
+	}
+	if options.ColumnDelimiter == "" {
+		options.ColumnDelimiter = "COMMA"
+	}
+	if options.ContentType == "" {
+		options.ContentType = "CSV"
+	}
+	if options.LineEnding == "" {
+		options.LineEnding = "LF"
+	}
+
+	switch _, validOperation := operations[options.Operation]; {
+	case options.Object == "":
+		writeError(w, http.StatusBadRequest, "INVALIDJOB", "object is required")
+		return
+	case validOperation == false:
+		writeError(w, http.StatusBadRequest, "INVALIDJOB", "invalid operation "+options.Operation)
+		return
+	case options.Operation == "upsert" && options.ExternalIDFieldName == "":
+		writeError(w, http.StatusBadRequest, "INVALIDJOB", "externalIdFieldName is required for upsert")
+		return
+	}
+	if _, has := columnDelimiters[options.ColumnDelimiter]; has == false {
+		writeError(w, http.StatusBadRequest, "INVALIDJOB", "invalid column delimiter "+options.ColumnDelimiter)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.counter++
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000+0000")
+	j := &job{
+		info: jobInfo{
+			APIVersion:          float32(version),
+			ColumnDelimiter:     options.ColumnDelimiter,
+			ConcurrencyMode:     "Parallel",
+			ContentType:         options.ContentType,
+			CreatedByID:         "005000000000001AAA",
+			CreatedDate:         now,
+			ExternalIDFieldName: options.ExternalIDFieldName,
+			ID:                  fmt.Sprintf("750%015d", s.counter),
+			JobType:             "V2Ingest",
+			LineEnding:          options.LineEnding,
+			Object:              options.Object,
+			Operation:           options.Operation,
+			State:               "Open",
+			SystemModstamp:      now,
+		},
+	}
+	j.info.ContentURL = fmt.Sprintf("services/data/v%d.0/jobs/ingest/%s/batches", version, j.info.ID)
+	s.jobs[j.info.ID] = j
+	s.jobOrder = append(s.jobOrder, j.info.ID)
+
+	writeJSON(w, http.StatusOK, j.info)
+}
+
+func (s *Server) listJobs(w http.ResponseWriter) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records := make([]jobInfo, len(s.jobOrder))
+	for idx, id := range s.jobOrder {
+		records[idx] = s.jobs[id].info
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"done":    true,
+		"records": records,
+	})
+}
+
+func (s *Server) setJobState(w http.ResponseWriter, r *http.Request, j *job) {
+	var request struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+		return
+	}
+	if j.info.State != "Open" {
+		writeError(w, http.StatusConflict, "INVALIDJOBSTATE", "Job is not open")
+		return
+	}
+
+	switch request.State {
+	case "UploadComplete":
+		j.info.State = "UploadComplete"
+		response := j.info
+		s.process(j)
+		writeJSON(w, http.StatusOK, response)
+	case "Aborted":
+		j.info.State = "Aborted"
+		if rows, err := j.rows(); err == nil && len(rows) > 0 {
+			j.header = rows[0]
+			j.unprocessed = rows[1:]
+		}
+		writeJSON(w, http.StatusOK, j.info)
+	default:
+		writeError(w, http.StatusBadRequest, "INVALIDJOBSTATE", "invalid state "+request.State)
+	}
+}
+
+// process runs the job's operation against the stored records.  The job is
+// processed synchronously, so it is complete once the upload is complete.
+func (s *Server) process(j *job) {
+	rows, err := j.rows()
+	if err != nil {
+		j.info.State = "Failed"
+		j.info.ErrorMessage = err.Error()
+		return
+	}
+	if len(rows) == 0 {
+		j.info.State = "JobComplete"
+		return
+	}
+
+	j.header = rows[0]
+	for _, row := range rows[1:] {
+		fields := map[string]interface{}{}
+		for idx, field := range j.header {
+			if idx >= len(row) || row[idx] == "" {
+				continue
+			}
+			if row[idx] == "#N/A" {
+				fields[field] = nil
+				continue
+			}
+			fields[field] = row[idx]
+		}
+
+		id, created, failure := s.processRecord(j.info, fields)
+		if failure != "" {
+			j.failed = append(j.failed, append([]string{id, failure}, row...))
+		} else {
+			j.successful = append(j.successful, append([]string{id, fmt.Sprintf("%t", created)}, row...))
+		}
+	}
+	j.info.NumberRecordsProcessed = len(rows) - 1
+	j.info.NumberRecordsFailed = len(j.failed)
+	j.info.State = "JobComplete"
+}
+
+func (s *Server) processRecord(info jobInfo, fields map[string]interface{}) (string, bool, string) {
+	id, _ := lookup(fields, "Id").(string)
+
+	switch info.Operation {
+	case "insert":
+		return s.insert(info.Object, fields), true, ""
+	case "upsert":
+		value := lookup(fields, info.ExternalIDFieldName)
+		if value == nil {
+			return "", false, "MISSING_ARGUMENT:" + info.ExternalIDFieldName + " not specified:--"
+		}
+		if record := s.findByField(info.Object, info.ExternalIDFieldName, value); record != nil {
+			s.update(record, fields)
+			return record["Id"].(string), false, ""
+		}
+		return s.insert(info.Object, fields), true, ""
+	}
+
+	record := s.find(info.Object, id)
+	if record == nil {
+		return id, false, "ENTITY_IS_DELETED:entity is deleted:--"
+	}
+	if info.Operation == "delete" {
+		s.remove(info.Object, id)
+	} else {
+		s.update(record, fields)
+	}
+	return id, false, ""
+}
+
+func (j *job) rows() ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(j.data.Bytes()))
+	reader.Comma = columnDelimiters[j.info.ColumnDelimiter]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return reader.ReadAll()
+}
+
+// writeResults writes the result rows unquoted, one record per line.
+func (j *job) writeResults(w http.ResponseWriter, header []string, rows [][]string) {
+	delimiter := string(columnDelimiters[j.info.ColumnDelimiter])
+	lineEnding := "\n"
+	if j.info.LineEnding == "CRLF" {
+		lineEnding = "\r\n"
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, strings.Join(header, delimiter)+lineEnding)
+	for _, row := range rows {
+		fmt.Fprint(w, strings.Join(row, delimiter)+lineEnding)
+	}
+}
@@ -0,0 +1,119 @@
+package sfdctest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc/bulk"
+)
+
+func TestServer_Bulk(t *testing.T) {
+	server, c := testClient(t)
+	existing := server.Insert("Account", map[string]interface{}{"Name": "Acme", "External__c": "A-1"})
+
+	resource, err := c.Bulk()
+	if err != nil {
+		t.Fatalf("Client.Bulk() error = %v", err)
+	}
+	job, err := resource.CreateJob(bulk.Options{
+		ColumnDelimiter:     bulk.Pipe,
+		ContentType:         bulk.CSV,
+		ExternalIDFieldName: "External__c",
+		LineEnding:          bulk.Linefeed,
+		Object:              "Account",
+		Operation:           bulk.Upsert,
+	})
+	if err != nil {
+		t.Fatalf("Resource.CreateJob() error = %v", err)
+	}
+
+	data := "External__c|Name\nA-1|Acme Corporation\nA-2|Globex\n|Missing\n"
+	if err := job.Upload(strings.NewReader(data)); err != nil {
+		t.Fatalf("Job.Upload() error = %v", err)
+	}
+	response, err := job.Close()
+	if err != nil {
+		t.Fatalf("Job.Close() error = %v", err)
+	}
+	if response.State != string(bulk.UpdateComplete) {
+		t.Errorf("Job.Close() state = %s, want %s", response.State, bulk.UpdateComplete)
+	}
+
+	info, err := job.Info()
+	if err != nil {
+		t.Fatalf("Job.Info() error = %v", err)
+	}
+	if info.State != string(bulk.JobComplete) || info.NumberRecordsProcessed != 3 || info.NumberRecordsFailed != 1 {
+		t.Errorf("Job.Info() = %+v", info)
+	}
+
+	successful, err := job.SuccessfulRecords()
+	if err != nil {
+		t.Fatalf("Job.SuccessfulRecords() error = %v", err)
+	}
+	if len(successful) != 2 {
+		t.Fatalf("Job.SuccessfulRecords() = %d, want 2", len(successful))
+	}
+	if successful[0].ID != existing || successful[0].Created || successful[1].Created == false {
+		t.Errorf("Job.SuccessfulRecords() = %+v", successful)
+	}
+	if successful[1].Fields["Name"] != "Globex" {
+		t.Errorf("Job.SuccessfulRecords() fields = %v", successful[1].Fields)
+	}
+
+	failed, err := job.FailedRecords()
+	if err != nil {
+		t.Fatalf("Job.FailedRecords() error = %v", err)
+	}
+	if len(failed) != 1 || strings.HasPrefix(failed[0].Error, "MISSING_ARGUMENT") == false {
+		t.Errorf("Job.FailedRecords() = %+v", failed)
+	}
+
+	if name := server.Record("Account", existing)["Name"]; name != "Acme Corporation" {
+		t.Errorf("Job.Close() Name = %v, want Acme Corporation", name)
+	}
+
+	if err := job.Delete(); err != nil {
+		t.Fatalf("Job.Delete() error = %v", err)
+	}
+	jobs, err := resource.AllJobs(bulk.Parameters{})
+	if err != nil {
+		t.Fatalf("Resource.AllJobs() error = %v", err)
+	}
+	if len(jobs.Records()) != 0 {
+		t.Errorf("Resource.AllJobs() = %v", jobs.Records())
+	}
+}
+
+func TestServer_Bulk_Abort(t *testing.T) {
+	server, c := testClient(t)
+
+	resource, err := c.Bulk()
+	if err != nil {
+		t.Fatalf("Client.Bulk() error = %v", err)
+	}
+	job, err := resource.CreateJob(bulk.Options{
+		Object:    "Contact",
+		Operation: bulk.Insert,
+	})
+	if err != nil {
+		t.Fatalf("Resource.CreateJob() error = %v", err)
+	}
+	if err := job.Upload(strings.NewReader("LastName\nSmith\nJones\n")); err != nil {
+		t.Fatalf("Job.Upload() error = %v", err)
+	}
+	if _, err := job.Abort(); err != nil {
+		t.Fatalf("Job.Abort() error = %v", err)
+	}
+
+	unprocessed, err := job.UnprocessedRecords()
+	if err != nil {
+		t.Fatalf("Job.UnprocessedRecords() error = %v", err)
+	}
+	if len(unprocessed) != 2 || unprocessed[1].Fields["LastName"] != "Jones" {
+		t.Errorf("Job.UnprocessedRecords() = %+v", unprocessed)
+	}
+	if records := server.Records("Contact"); len(records) != 0 {
+		t.Errorf("Job.Abort() records = %v", records)
+	}
+}
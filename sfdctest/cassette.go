@@ -0,0 +1,499 @@
+package sfdctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode is how the recorder uses the cassette.
+type Mode int
+
+const (
+	// ModeReplay will only replay the cassette.  A request that was not
+	// recorded is an error.
+	ModeReplay Mode = iota
+	// ModeRecord will send the requests and record the interactions.
+	ModeRecord
+	// ModeAuto will replay the cassette when the file exists, otherwise the
+	// interactions are recorded.
+	ModeAuto
+)
+
+// Match is the parts of the request that are used to match a recorded interaction.
+type Match int
+
+const (
+	// MatchMethod matches the HTTP method.
+	MatchMethod Match = 1 << iota
+	// MatchPath matches the URL path.
+	MatchPath
+	// MatchQuery matches the URL query parameters, in any order.
+	MatchQuery
+	// MatchBody matches the request body.  JSON bodies are matched by value.
+	MatchBody
+)
+
+// DefaultMatch matches the method, path and query.
+const DefaultMatch = MatchMethod | MatchPath | MatchQuery
+
+const (
+	// Redacted replaces the secrets in the cassette.
+	Redacted = "REDACTED"
+	// RedactedHost replaces the hosts in the cassette.
+	RedactedHost = "instance.salesforce.example"
+)
+
+var secretForms = []string{"password", "client_secret", "refresh_token", "assertion", "code", "code_verifier", "token"}
+var secretFields = []string{"access_token", "refresh_token", "signature", "id_token"}
+var secretJSON = regexp.MustCompile(`("(?:` + strings.Join(secretFields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// minSecretLength is the shortest secret that is replaced wherever it is
+// found.  Shorter secrets are only redacted from their fields, so that they
+// do not replace unrelated text.
+const minSecretLength = 16
+
+// Cassette is the recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded HTTP response.  The body is base64
+// encoded when it is not valid UTF-8.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// Recorder is a round tripper that records the request and response pairs to
+// a cassette file and replays them.  Access tokens, passwords, client secrets
+// and the hosts are redacted when the cassette is saved.  The secrets are
+// redacted from their form fields, query parameters, JSON fields and the
+// Authorization header, and the long secrets are also replaced wherever they
+// are found.
+//
+// Match is the parts of the request used to match the recorded interactions.
+// If zero, the DefaultMatch is used.
+//
+// Transport is the round tripper used to record the interactions.  If nil,
+// the http.DefaultTransport is used.
+//
+// Redact is called for each interaction before the cassette is saved, so that
+// other sensitive values can be redacted.  This field is optional.
+type Recorder struct {
+	Match     Match
+	Transport http.RoundTripper
+	Redact    func(interaction *Interaction)
+
+	path      string
+	recording bool
+	mutex     sync.Mutex
+	cassette  Cassette
+	replayed  []bool
+	secrets   map[string]struct{}
+	hosts     map[string]struct{}
+}
+
+// NewRecorder will create a recorder with the cassette file.  In replay, the
+// cassette file is loaded.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	if path == "" {
+		return nil, errors.New("sfdctest: cassette path can not be empty")
+	}
+	recorder := &Recorder{
+		path:    path,
+		secrets: make(map[string]struct{}),
+		hosts:   make(map[string]struct{}),
+	}
+
+	switch mode {
+	case ModeRecord:
+		recorder.recording = true
+		return recorder, nil
+	case ModeAuto:
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			recorder.recording = true
+			return recorder, nil
+		}
+	case ModeReplay:
+	default:
+		return nil, fmt.Errorf("sfdctest: invalid cassette mode %d", mode)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+		return nil, fmt.Errorf("sfdctest: unable to decode cassette %s: %w", path, err)
+	}
+	recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	return recorder, nil
+}
+
+// Recording returns true if the recorder is recording the interactions.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// RoundTrip will record or replay the request.
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, outgoing, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+	if r.recording {
+		return r.record(outgoing, body)
+	}
+	return r.replay(outgoing, body)
+}
+
+// Save will write the redacted cassette file.  Nothing is written when the
+// cassette is replayed.
+func (r *Recorder) Save() error {
+	if r.recording == false {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cassette := Cassette{
+		Interactions: make([]Interaction, len(r.cassette.Interactions)),
+	}
+	for idx, interaction := range r.cassette.Interactions {
+		interaction = r.redact(interaction)
+		if r.Redact != nil {
+			r.Redact(&interaction)
+		}
+		cassette.Interactions[idx] = interaction
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(cassette); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, buffer.Bytes(), 0644)
+}
+
+func (r *Recorder) record(request *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: request.Header.Clone(),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     response.Header.Clone(),
+			Body:       string(responseBody),
+		},
+	}
+	if utf8.Valid(responseBody) == false {
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(responseBody)
+		interaction.Response.Base64 = true
+	}
+
+	r.mutex.Lock()
+	r.learn(request, body, responseBody)
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mutex.Unlock()
+
+	return response, nil
+}
+
+func (r *Recorder) replay(request *http.Request, body []byte) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	last := -1
+	for idx, interaction := range r.cassette.Interactions {
+		if r.matches(request, body, interaction.Request) == false {
+			continue
+		}
+		last = idx
+		if r.replayed[idx] == false {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("sfdctest: no recorded interaction for %s %s", request.Method, request.URL.String())
+	}
+	r.replayed[last] = true
+
+	recorded := r.cassette.Interactions[last].Response
+	responseBody := []byte(recorded.Body)
+	if recorded.Base64 {
+		decoded, err := base64.StdEncoding.DecodeString(recorded.Body)
+		if err != nil {
+			return nil, err
+		}
+		responseBody = decoded
+	}
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}, nil
+}
+
+func (r *Recorder) matches(request *http.Request, body []byte, recorded RecordedRequest) bool {
+	match := r.Match
+	if match == 0 {
+		match = DefaultMatch
+	}
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	switch {
+	case match&MatchMethod != 0 && request.Method != recorded.Method:
+		return false
+	case match&MatchPath != 0 && request.URL.Path != recordedURL.Path:
+		return false
+	case match&MatchQuery != 0 && reflect.DeepEqual(normalizeQuery(request.URL.Query()), normalizeQuery(recordedURL.Query())) == false:
+		return false
+	case match&MatchBody != 0 && equalBodies(redactForm(request.Header, string(body)), recorded.Body) == false:
+		return false
+	}
+	return true
+}
+
+// learn collects the secrets and hosts of the interaction, so that they can
+// be redacted from every interaction.
+func (r *Recorder) learn(request *http.Request, body, responseBody []byte) {
+	r.hosts[request.URL.Host] = struct{}{}
+	r.learnSecret(strings.TrimSpace(strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer")))
+	if form, err := url.ParseQuery(string(body)); err == nil {
+		for _, key := range secretForms {
+			r.learnSecret(form.Get(key))
+		}
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(responseBody, &values); err == nil {
+		for _, key := range secretFields {
+			if value, ok := values[key].(string); ok {
+				r.learnSecret(value)
+			}
+		}
+		if instance, ok := values["instance_url"].(string); ok {
+			if instanceURL, err := url.Parse(instance); err == nil && instanceURL.Host != "" {
+				r.hosts[instanceURL.Host] = struct{}{}
+			}
+		}
+	}
+}
+
+func (r *Recorder) learnSecret(secret string) {
+	if len(secret) >= minSecretLength {
+		r.secrets[secret] = struct{}{}
+	}
+}
+
+func (r *Recorder) redact(interaction Interaction) Interaction {
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	// longer secrets are replaced first, in case a secret contains another
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	replaceHosts := func(value string) string {
+		for host := range r.hosts {
+			value = strings.Replace(value, host, RedactedHost, -1)
+		}
+		return value
+	}
+	replace := func(value string) string {
+		for _, secret := range secrets {
+			value = strings.Replace(value, secret, Redacted, -1)
+		}
+		return replaceHosts(value)
+	}
+	header := func(header http.Header) http.Header {
+		redacted := http.Header{}
+		for key, values := range header {
+			switch http.CanonicalHeaderKey(key) {
+			case "Authorization":
+				redacted[key] = []string{"Bearer " + Redacted}
+			case "Cookie", "Set-Cookie":
+			default:
+				for _, value := range values {
+					redacted[key] = append(redacted[key], replace(value))
+				}
+			}
+		}
+		return redacted
+	}
+
+	interaction.Request.URL = replace(redactQuery(interaction.Request.URL))
+	interaction.Response.Header.Del("Content-Length")
+	interaction.Request.Header = header(interaction.Request.Header)
+	if isForm(interaction.Request.Header) {
+		interaction.Request.Body = replaceHosts(redactForm(interaction.Request.Header, interaction.Request.Body))
+	} else {
+		interaction.Request.Body = replace(redactJSON(interaction.Request.Body))
+	}
+	interaction.Response.Header = header(interaction.Response.Header)
+	if interaction.Response.Base64 == false {
+		interaction.Response.Body = replace(redactJSON(interaction.Response.Body))
+	}
+	return interaction
+}
+
+// redactQuery replaces the secret values of the URL's query.
+func redactQuery(rawURL string) string {
+	requestURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := requestURL.Query()
+	redacted := false
+	for _, key := range secretForms {
+		if _, has := query[key]; has {
+			query.Set(key, Redacted)
+			redacted = true
+		}
+	}
+	if redacted == false {
+		return rawURL
+	}
+	requestURL.RawQuery = query.Encode()
+	return requestURL.String()
+}
+
+// redactJSON replaces the values of the secret fields in a JSON body.  Other
+// bodies are not changed.
+func redactJSON(body string) string {
+	return secretJSON.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+}
+
+// redactForm replaces the secret values of a form body.  Other bodies are
+// not changed.
+func redactForm(header http.Header, body string) string {
+	if isForm(header) == false {
+		return body
+	}
+	form, err := url.ParseQuery(body)
+	if err != nil {
+		return body
+	}
+	for _, key := range secretForms {
+		if _, has := form[key]; has {
+			form.Set(key, Redacted)
+		}
+	}
+	return form.Encode()
+}
+
+func isForm(header http.Header) bool {
+	return strings.HasPrefix(header.Get("Content-Type"), "application/x-www-form-urlencoded")
+}
+
+func normalizeQuery(values url.Values) url.Values {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func equalBodies(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// readBody returns the request body and the request to send with it.  The
+// caller's request is not changed, so the body is read from GetBody when it is
+// able to be, and the request that is sent is a clone with its own body.  The
+// caller's body is closed, as a round tripper must do.
+func readBody(request *http.Request) ([]byte, *http.Request, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, request, nil
+	}
+	defer request.Body.Close()
+
+	reader := request.Body
+	if request.GetBody != nil {
+		getBody, err := request.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer getBody.Close()
+		reader = getBody
+	}
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outgoing := request.Clone(request.Context())
+	outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	outgoing.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, outgoing, nil
+}
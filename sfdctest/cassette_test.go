@@ -0,0 +1,323 @@
+package sfdctest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/client"
+	"github.com/g8rswimmer/go-sfdc/composite"
+)
+
+func TestNewRecorder(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.json")
+	if err := ioutil.WriteFile(existing, []byte(`{"interactions":[]}`), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := ioutil.WriteFile(invalid, []byte(`not json`), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		mode          Mode
+		wantRecording bool
+		wantErr       bool
+	}{
+		{
+			name:          "Record",
+			path:          filepath.Join(dir, "record.json"),
+			mode:          ModeRecord,
+			wantRecording: true,
+			wantErr:       false,
+		},
+		{
+			name:          "Replay",
+			path:          existing,
+			mode:          ModeReplay,
+			wantRecording: false,
+			wantErr:       false,
+		},
+		{
+			name:          "Auto Record",
+			path:          filepath.Join(dir, "auto.json"),
+			mode:          ModeAuto,
+			wantRecording: true,
+			wantErr:       false,
+		},
+		{
+			name:          "Auto Replay",
+			path:          existing,
+			mode:          ModeAuto,
+			wantRecording: false,
+			wantErr:       false,
+		},
+		{
+			name:    "Replay Missing",
+			path:    filepath.Join(dir, "missing.json"),
+			mode:    ModeReplay,
+			wantErr: true,
+		},
+		{
+			name:    "Replay Invalid",
+			path:    invalid,
+			mode:    ModeReplay,
+			wantErr: true,
+		},
+		{
+			name:    "No Path",
+			mode:    ModeRecord,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Mode",
+			path:    existing,
+			mode:    Mode(10),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRecorder(tt.path, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRecorder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Recording() != tt.wantRecording {
+				t.Errorf("NewRecorder() recording = %v, want %v", got.Recording(), tt.wantRecording)
+			}
+		})
+	}
+}
+
+func testRecorderClient(t *testing.T, config sfdc.Configuration, recorder *Recorder) *client.Client {
+	config.Client = &http.Client{
+		Transport: recorder,
+	}
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	return c
+}
+
+func testRecordedCalls(t *testing.T, c *client.Client) []string {
+	var names []string
+	soqlResource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	result, err := soqlResource.Query(testSOQL("SELECT Name FROM Account ORDER BY Name"), false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	for {
+		for _, record := range result.Records() {
+			name, _ := record.Record().FieldValue("Name")
+			names = append(names, name.(string))
+		}
+		if result.MoreRecords() == false {
+			break
+		}
+		if result, err = result.Next(); err != nil {
+			t.Fatalf("QueryResult.Next() error = %v", err)
+		}
+	}
+
+	compositeResource, err := c.Composite()
+	if err != nil {
+		t.Fatalf("Client.Composite() error = %v", err)
+	}
+	value, err := compositeResource.Retrieve(false, []composite.Subrequester{
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Account",
+			referenceID: "NewAccount",
+			method:      http.MethodPost,
+			body:        map[string]interface{}{"Name": "Initech"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Retrieve() error = %v", err)
+	}
+	names = append(names, value.Response[0].Body.(map[string]interface{})["id"].(string))
+	return names
+}
+
+func TestRecorder(t *testing.T) {
+	server := NewServer()
+	server.Insert("Account", map[string]interface{}{"Name": "Acme"})
+	server.Insert("Account", map[string]interface{}{"Name": "Globex"})
+	server.QueryBatchSize = 1
+	config := server.Configuration()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	recorder.Transport = server.Client().Transport
+	recorded := testRecordedCalls(t, testRecorderClient(t, config, recorder))
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Recorder.Save() error = %v", err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cassette := string(data)
+	for _, secret := range []string{"00Dsfdctest!", `"password"`, strings.TrimPrefix(server.URL(), "http://")} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("Recorder.Save() cassette contains %s", secret)
+		}
+	}
+	if strings.Contains(cassette, RedactedHost) == false || strings.Contains(cassette, Redacted) == false {
+		t.Error("Recorder.Save() cassette is not redacted")
+	}
+
+	recorder, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	recorder.Match = DefaultMatch | MatchBody
+	replayed := testRecordedCalls(t, testRecorderClient(t, config, recorder))
+	if strings.Join(replayed, ",") != strings.Join(recorded, ",") {
+		t.Errorf("Recorder replayed %v, want %v", replayed, recorded)
+	}
+
+	resource, err := testRecorderClient(t, config, recorder).SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	_, err = resource.Query(testSOQL("SELECT Id FROM Contact"), false)
+	if err == nil || strings.Contains(err.Error(), "no recorded interaction") == false {
+		t.Errorf("Recorder unrecorded request error = %v", err)
+	}
+}
+
+func TestRecorder_RoundTrip_Request(t *testing.T) {
+	tests := []struct {
+		name    string
+		getBody bool
+	}{
+		{
+			name:    "Get Body",
+			getBody: true,
+		},
+		{
+			name:    "Body Only",
+			getBody: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, err := NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), ModeRecord)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			var sent string
+			recorder.Transport = sfdc.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(request.Body)
+				sent = string(body)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("{}")),
+					Header:     make(http.Header),
+				}, nil
+			})
+
+			request, err := http.NewRequest(http.MethodPost, "https://test.salesforce.com/services/data/v45.0/sobjects/Account", strings.NewReader(`{"Name":"Acme"}`))
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if tt.getBody == false {
+				request.GetBody = nil
+			}
+			body := request.Body
+
+			response, err := recorder.RoundTrip(request)
+			if err != nil {
+				t.Fatalf("Recorder.RoundTrip() error = %v", err)
+			}
+			response.Body.Close()
+
+			if request.Body != body {
+				t.Error("Recorder.RoundTrip() replaced the request body")
+			}
+			if sent != `{"Name":"Acme"}` {
+				t.Errorf("Recorder.RoundTrip() sent body = %s", sent)
+			}
+			if got := recorder.cassette.Interactions[0].Request.Body; got != `{"Name":"Acme"}` {
+				t.Errorf("Recorder.RoundTrip() recorded body = %s", got)
+			}
+		})
+	}
+}
+
+func TestRecorder_Redact_ShortSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	recorder.Transport = sfdc.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		body := `{"access_token":"tok","instance_url":"https://na1.salesforce.com","token_type":"Bearer"}`
+		if request.URL.Path != "/services/oauth2/token" {
+			body = `{"totalSize":1,"done":true,"records":[{"Name":"tok a1 Account","Password__c":"a1"}]}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	token, err := http.NewRequest(http.MethodPost, "https://login.salesforce.com/services/oauth2/token", strings.NewReader("grant_type=password&username=user&password=a1"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	token.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	query, err := http.NewRequest(http.MethodGet, "https://na1.salesforce.com/services/data/v45.0/query/?q=SELECT+Name+FROM+Account", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	query.Header.Set("Authorization", "Bearer tok")
+	for _, request := range []*http.Request{token, query} {
+		response, err := recorder.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("Recorder.RoundTrip() error = %v", err)
+		}
+		response.Body.Close()
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Recorder.Save() error = %v", err)
+	}
+
+	replay, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	interactions := replay.cassette.Interactions
+	if len(interactions) != 2 {
+		t.Fatalf("Recorder.Save() interactions = %d, want 2", len(interactions))
+	}
+	if got := interactions[0].Request.Body; strings.Contains(got, "password="+Redacted) == false {
+		t.Errorf("Recorder.Save() token request = %s", got)
+	}
+	if got := interactions[0].Response.Body; strings.Contains(got, `"access_token":"`+Redacted+`"`) == false {
+		t.Errorf("Recorder.Save() token response = %s", got)
+	}
+	if got := interactions[1].Request.Header.Get("Authorization"); got != "Bearer "+Redacted {
+		t.Errorf("Recorder.Save() authorization = %s", got)
+	}
+	want := `{"totalSize":1,"done":true,"records":[{"Name":"tok a1 Account","Password__c":"a1"}]}`
+	if got := interactions[1].Response.Body; got != want {
+		t.Errorf("Recorder.Save() query response = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,360 @@
+package sfdctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var referencePattern = regexp.MustCompile(`@\{([^.}]+)\.([^}]+)\}`)
+
+type compositeRequest struct {
+	AllOrNone bool `json:"allOrNone"`
+	Requests  []struct {
+		Method      string      `json:"method"`
+		URL         string      `json:"url"`
+		ReferenceID string      `json:"referenceId"`
+		Body        interface{} `json:"body"`
+	} `json:"compositeRequest"`
+}
+
+type batchRequest struct {
+	HaltOnError bool `json:"haltOnError"`
+	Requests    []struct {
+		Method    string      `json:"method"`
+		URL       string      `json:"url"`
+		RichInput interface{} `json:"richInput"`
+	} `json:"batchRequests"`
+}
+
+type collectionRequest struct {
+	AllOrNone bool                     `json:"allOrNone"`
+	Records   []map[string]interface{} `json:"records"`
+	IDs       []string                 `json:"ids"`
+	Fields    []string                 `json:"fields"`
+}
+
+func (s *Server) composite(w http.ResponseWriter, r *http.Request, version int) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+		return
+	}
+	var request compositeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+		return
+	}
+
+	snapshot := s.snapshot()
+	bodies := map[string]interface{}{}
+	responses := make([]map[string]interface{}, len(request.Requests))
+	failed := false
+	for idx, subrequest := range request.Requests {
+		response := map[string]interface{}{
+			"httpHeaders": map[string]string{},
+			"referenceId": subrequest.ReferenceID,
+		}
+		if failed && request.AllOrNone {
+			response["httpStatusCode"] = http.StatusBadRequest
+			response["body"] = []map[string]interface{}{
+				{"errorCode": "PROCESSING_HALTED", "message": "The transaction was rolled back since another operation in the same transaction failed."},
+			}
+			responses[idx] = response
+			continue
+		}
+		status, body := s.dispatch(subrequest.Method, resolve(subrequest.URL, bodies), resolveBody(subrequest.Body, bodies))
+		response["httpStatusCode"] = status
+		response["body"] = body
+		responses[idx] = response
+		bodies[subrequest.ReferenceID] = body
+		if status >= http.StatusBadRequest {
+			failed = true
+		}
+	}
+	if failed && request.AllOrNone {
+		s.restore(snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"compositeResponse": responses,
+	})
+}
+
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+		return
+	}
+	var request batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+		return
+	}
+
+	results := make([]map[string]interface{}, len(request.Requests))
+	hasErrors := false
+	for idx, subrequest := range request.Requests {
+		if hasErrors && request.HaltOnError {
+			results[idx] = map[string]interface{}{
+				"statusCode": http.StatusPreconditionFailed,
+				"result": []map[string]interface{}{
+					{"errorCode": "BATCH_PROCESSING_HALTED", "message": "Batch processing halted per request"},
+				},
+			}
+			continue
+		}
+		url := subrequest.URL
+		if strings.HasPrefix(url, "/") == false {
+			url = "/services/data/" + url
+		}
+		status, result := s.dispatch(subrequest.Method, url, subrequest.RichInput)
+		results[idx] = map[string]interface{}{
+			"statusCode": status,
+			"result":     result,
+		}
+		if status >= http.StatusBadRequest {
+			hasErrors = true
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"hasErrors": hasErrors,
+		"results":   results,
+	})
+}
+
+// dispatch routes a subrequest of the composite APIs and returns the status
+// code and decoded body of the response.
+func (s *Server) dispatch(method, url string, body interface{}) (int, interface{}) {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	request, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return http.StatusBadRequest, []map[string]string{
+			{"errorCode": "INVALID_URL", "message": err.Error()},
+		}
+	}
+	matches := versionPath.FindStringSubmatch(request.URL.Path)
+	if matches == nil {
+		return http.StatusNotFound, []map[string]string{
+			{"errorCode": "NOT_FOUND", "message": "The requested resource does not exist"},
+		}
+	}
+	version, _ := strconv.Atoi(matches[1])
+
+	recorder := httptest.NewRecorder()
+	s.route(recorder, request, version, matches[2])
+
+	var result interface{}
+	if recorder.Body.Len() > 0 {
+		json.Unmarshal(recorder.Body.Bytes(), &result)
+	}
+	return recorder.Code, result
+}
+
+// resolve replaces the @{referenceId.field} references with the values from
+// the earlier subrequest responses.
+func resolve(value string, bodies map[string]interface{}) string {
+	return referencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		matches := referencePattern.FindStringSubmatch(reference)
+		body, has := bodies[matches[1]]
+		if has == false {
+			return reference
+		}
+		resolved := path(body, matches[2])
+		if resolved == nil {
+			return reference
+		}
+		switch v := resolved.(type) {
+		case string:
+			return v
+		default:
+			encoded, _ := json.Marshal(v)
+			return string(encoded)
+		}
+	})
+}
+
+func resolveBody(body interface{}, bodies map[string]interface{}) interface{} {
+	if body == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return body
+	}
+	var resolved interface{}
+	if err := json.Unmarshal([]byte(resolve(string(encoded), bodies)), &resolved); err != nil {
+		return body
+	}
+	return resolved
+}
+
+// path follows a reference path, like records[0].Id, through the value.
+func path(value interface{}, reference string) interface{} {
+	for _, part := range strings.Split(reference, ".") {
+		index := -1
+		if open := strings.Index(part, "["); open >= 0 && strings.HasSuffix(part, "]") {
+			index, _ = strconv.Atoi(part[open+1 : len(part)-1])
+			part = part[:open]
+		}
+		record, ok := value.(map[string]interface{})
+		if ok == false {
+			return nil
+		}
+		value = record[part]
+		if index >= 0 {
+			array, ok := value.([]interface{})
+			if ok == false || index >= len(array) {
+				return nil
+			}
+			value = array[index]
+		}
+	}
+	return value
+}
+
+func (s *Server) collections(w http.ResponseWriter, r *http.Request, version int, sobject string) {
+	var request collectionRequest
+	if r.Method != http.MethodDelete {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+			return
+		}
+	}
+
+	switch {
+	case sobject != "" && r.Method == http.MethodPost:
+		s.collectionRetrieve(w, version, sobject, request)
+	case sobject == "" && r.Method == http.MethodPost:
+		s.collectionSave(w, request, true)
+	case sobject == "" && r.Method == http.MethodPatch:
+		s.collectionSave(w, request, false)
+	case sobject == "" && r.Method == http.MethodDelete:
+		request.AllOrNone = r.URL.Query().Get("allOrNone") == "true"
+		if ids := r.URL.Query().Get("ids"); ids != "" {
+			request.IDs = strings.Split(ids, ",")
+		}
+		s.collectionDelete(w, request)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+	}
+}
+
+func (s *Server) collectionSave(w http.ResponseWriter, request collectionRequest, insert bool) {
+	snapshot := s.snapshot()
+
+	s.mutex.Lock()
+	results := make([]map[string]interface{}, len(request.Records))
+	failed := false
+	for idx, record := range request.Records {
+		attrs, _ := record["attributes"].(map[string]interface{})
+		sobject, _ := attrs["type"].(string)
+		if sobject == "" {
+			results[idx] = saveResult("", false, recordError("INVALID_TYPE", "sObject type is required"))
+			failed = true
+			continue
+		}
+		if insert {
+			results[idx] = saveResult(s.insert(sobject, record), true)
+			continue
+		}
+		id, _ := lookup(record, "Id").(string)
+		existing := s.find(sobject, id)
+		if existing == nil {
+			results[idx] = saveResult(id, false, recordError("ENTITY_IS_DELETED", "entity is deleted"))
+			failed = true
+			continue
+		}
+		s.update(existing, record)
+		results[idx] = saveResult(id, true)
+	}
+	s.mutex.Unlock()
+
+	if failed && request.AllOrNone {
+		s.restore(snapshot)
+		results = rolledBack(results)
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) collectionDelete(w http.ResponseWriter, request collectionRequest) {
+	snapshot := s.snapshot()
+
+	s.mutex.Lock()
+	results := make([]map[string]interface{}, len(request.IDs))
+	failed := false
+	for idx, id := range request.IDs {
+		sobject, has := s.sobjectOf(id)
+		if has == false {
+			results[idx] = saveResult(id, false, recordError("ENTITY_IS_DELETED", "entity is deleted"))
+			failed = true
+			continue
+		}
+		s.remove(sobject, id)
+		results[idx] = saveResult(id, true)
+	}
+	s.mutex.Unlock()
+
+	if failed && request.AllOrNone {
+		s.restore(snapshot)
+		results = rolledBack(results)
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) collectionRetrieve(w http.ResponseWriter, version int, sobject string, request collectionRequest) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records := make([]interface{}, len(request.IDs))
+	for idx, id := range request.IDs {
+		if record := s.find(sobject, id); record != nil {
+			records[idx] = project(version, s.table(sobject).name, record, request.Fields)
+		}
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+func rolledBack(results []map[string]interface{}) []map[string]interface{} {
+	for idx, result := range results {
+		if result["success"] == true {
+			id, _ := result["id"].(string)
+			results[idx] = saveResult(id, false, recordError("ALL_OR_NONE_OPERATION_ROLLED_BACK", "Record rolled back because not all records were valid and the request was using AllOrNone header"))
+		}
+	}
+	return results
+}
+
+// snapshot copies the stored records, so that they can be restored when an
+// all or none request fails.
+func (s *Server) snapshot() map[string]*table {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tables := make(map[string]*table, len(s.tables))
+	for key, t := range s.tables {
+		copied := &table{
+			name:    t.name,
+			prefix:  t.prefix,
+			order:   append([]string(nil), t.order...),
+			records: make(map[string]map[string]interface{}, len(t.records)),
+		}
+		for id, record := range t.records {
+			copied.records[id] = copyRecord(record)
+		}
+		tables[key] = copied
+	}
+	return tables
+}
+
+func (s *Server) restore(tables map[string]*table) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tables = tables
+}
@@ -0,0 +1,217 @@
+package sfdctest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc/composite"
+	"github.com/g8rswimmer/go-sfdc/composite/batch"
+	"github.com/g8rswimmer/go-sfdc/sobject"
+)
+
+type testSubrequest struct {
+	url         string
+	referenceID string
+	method      string
+	body        map[string]interface{}
+}
+
+func (r *testSubrequest) URL() string {
+	return r.url
+}
+func (r *testSubrequest) ReferenceID() string {
+	return r.referenceID
+}
+func (r *testSubrequest) Method() string {
+	return r.method
+}
+func (r *testSubrequest) HTTPHeaders() http.Header {
+	return nil
+}
+func (r *testSubrequest) Body() map[string]interface{} {
+	return r.body
+}
+func (r *testSubrequest) BinaryPartName() string {
+	return ""
+}
+func (r *testSubrequest) BinaryPartNameAlias() string {
+	return ""
+}
+func (r *testSubrequest) RichInput() map[string]interface{} {
+	return r.body
+}
+
+func TestServer_Composite(t *testing.T) {
+	server, c := testClient(t)
+
+	resource, err := c.Composite()
+	if err != nil {
+		t.Fatalf("Client.Composite() error = %v", err)
+	}
+	value, err := resource.Retrieve(true, []composite.Subrequester{
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Account",
+			referenceID: "NewAccount",
+			method:      http.MethodPost,
+			body:        map[string]interface{}{"Name": "Acme"},
+		},
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Contact",
+			referenceID: "NewContact",
+			method:      http.MethodPost,
+			body:        map[string]interface{}{"LastName": "Smith", "AccountId": "@{NewAccount.id}"},
+		},
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Account/@{NewAccount.id}?fields=Name",
+			referenceID: "GetAccount",
+			method:      http.MethodGet,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Retrieve() error = %v", err)
+	}
+	if len(value.Response) != 3 {
+		t.Fatalf("Resource.Retrieve() responses = %d, want 3", len(value.Response))
+	}
+	for _, response := range value.Response {
+		if response.HTTPStatusCode >= http.StatusBadRequest {
+			t.Errorf("Resource.Retrieve() %s status = %d", response.ReferenceID, response.HTTPStatusCode)
+		}
+	}
+
+	accountID := value.Response[0].Body.(map[string]interface{})["id"]
+	contacts := server.Records("Contact")
+	if len(contacts) != 1 || contacts[0]["AccountId"] != accountID {
+		t.Errorf("Resource.Retrieve() contacts = %v, want AccountId %v", contacts, accountID)
+	}
+	if name := value.Response[2].Body.(map[string]interface{})["Name"]; name != "Acme" {
+		t.Errorf("Resource.Retrieve() Name = %v, want Acme", name)
+	}
+}
+
+func TestServer_Composite_AllOrNone(t *testing.T) {
+	server, c := testClient(t)
+
+	resource, err := c.Composite()
+	if err != nil {
+		t.Fatalf("Client.Composite() error = %v", err)
+	}
+	value, err := resource.Retrieve(true, []composite.Subrequester{
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Account",
+			referenceID: "NewAccount",
+			method:      http.MethodPost,
+			body:        map[string]interface{}{"Name": "Acme"},
+		},
+		&testSubrequest{
+			url:         "/services/data/v45.0/sobjects/Account/001000000000000999",
+			referenceID: "Missing",
+			method:      http.MethodPatch,
+			body:        map[string]interface{}{"Name": "Missing"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Retrieve() error = %v", err)
+	}
+	if value.Response[1].HTTPStatusCode != http.StatusNotFound {
+		t.Errorf("Resource.Retrieve() status = %d, want %d", value.Response[1].HTTPStatusCode, http.StatusNotFound)
+	}
+	if records := server.Records("Account"); len(records) != 0 {
+		t.Errorf("Resource.Retrieve() did not roll back %v", records)
+	}
+}
+
+func TestServer_Batch(t *testing.T) {
+	server, c := testClient(t)
+	id := server.Insert("Account", map[string]interface{}{"Name": "Acme"})
+
+	resource, err := c.Batch()
+	if err != nil {
+		t.Fatalf("Client.Batch() error = %v", err)
+	}
+	value, err := resource.Retrieve(false, []batch.Subrequester{
+		&testSubrequest{
+			url:    "v45.0/sobjects/Account/" + id,
+			method: http.MethodPatch,
+			body:   map[string]interface{}{"Name": "Globex"},
+		},
+		&testSubrequest{
+			url:    "v45.0/query/?q=SELECT+Name+FROM+Account",
+			method: http.MethodGet,
+		},
+		&testSubrequest{
+			url:    "v45.0/sobjects/Account/001000000000000999",
+			method: http.MethodGet,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Retrieve() error = %v", err)
+	}
+	if value.HasErrors == false {
+		t.Error("Resource.Retrieve() has errors = false, want true")
+	}
+	statuses := []int{http.StatusNoContent, http.StatusOK, http.StatusNotFound}
+	for idx, result := range value.Results {
+		if result.StatusCode != statuses[idx] {
+			t.Errorf("Resource.Retrieve() result %d status = %d, want %d", idx, result.StatusCode, statuses[idx])
+		}
+	}
+	if server.Record("Account", id)["Name"] != "Globex" {
+		t.Errorf("Resource.Retrieve() did not update the record")
+	}
+}
+
+func TestServer_Collections(t *testing.T) {
+	server, c := testClient(t)
+	resource, err := c.Collections()
+	if err != nil {
+		t.Fatalf("Client.Collections() error = %v", err)
+	}
+
+	inserted, err := resource.Insert(true, []sobject.Inserter{
+		&testRecord{sobject: "Account", fields: map[string]interface{}{"Name": "Acme"}},
+		&testRecord{sobject: "Account", fields: map[string]interface{}{"Name": "Globex"}},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Insert() error = %v", err)
+	}
+	if len(inserted) != 2 || inserted[0].Success == false || inserted[1].Success == false {
+		t.Fatalf("Resource.Insert() = %+v", inserted)
+	}
+
+	updated, err := resource.Update(true, []sobject.Updater{
+		&testRecord{sobject: "Account", id: inserted[0].ID, fields: map[string]interface{}{"Name": "Initech"}},
+		&testRecord{sobject: "Account", id: "001000000000000999", fields: map[string]interface{}{"Name": "Missing"}},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Update() error = %v", err)
+	}
+	if updated[0].Success || updated[1].Success {
+		t.Errorf("Resource.Update() = %+v, want all or none to fail", updated)
+	}
+	if server.Record("Account", inserted[0].ID)["Name"] != "Acme" {
+		t.Error("Resource.Update() did not roll back")
+	}
+
+	records, err := resource.Query("Account", []sobject.Querier{
+		&testQuerier{sobject: "Account", id: inserted[0].ID, fields: []string{"Name"}},
+		&testQuerier{sobject: "Account", id: inserted[1].ID, fields: []string{"Name"}},
+	})
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Resource.Query() records = %d, want 2", len(records))
+	}
+
+	deleted, err := resource.Delete(false, []string{inserted[0].ID, inserted[1].ID})
+	if err != nil {
+		t.Fatalf("Resource.Delete() error = %v", err)
+	}
+	if len(deleted) != 2 || deleted[0].Success == false || deleted[1].Success == false {
+		t.Errorf("Resource.Delete() = %+v", deleted)
+	}
+	if records := server.Records("Account"); len(records) != 0 {
+		t.Errorf("Resource.Delete() records = %v", records)
+	}
+}
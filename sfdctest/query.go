@@ -0,0 +1,704 @@
+package sfdctest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	stringToken
+	numberToken
+	operatorToken
+	punctToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+type orderField struct {
+	field      string
+	descending bool
+}
+
+type condition func(record map[string]interface{}) bool
+
+// statement is a parsed SOQL query.  Only SELECT, FROM, WHERE, ORDER BY,
+// LIMIT and OFFSET are supported.
+type statement struct {
+	fields  []string
+	count   bool
+	sobject string
+	where   condition
+	orderBy []orderField
+	limit   int
+	offset  int
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func (s *Server) query(w http.ResponseWriter, r *http.Request, version int) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+		return
+	}
+	stmt, err := parseStatement(r.URL.Query().Get("q"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "MALFORMED_QUERY", err.Error())
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records := []map[string]interface{}{}
+	for _, record := range s.table(stmt.sobject).list() {
+		if stmt.where == nil || stmt.where(record) {
+			records = append(records, record)
+		}
+	}
+	if len(stmt.orderBy) > 0 {
+		sort.SliceStable(records, func(i, j int) bool {
+			for _, order := range stmt.orderBy {
+				result := compare(lookup(records[i], order.field), lookup(records[j], order.field))
+				if result == 0 {
+					continue
+				}
+				if order.descending {
+					return result > 0
+				}
+				return result < 0
+			}
+			return false
+		})
+	}
+	if stmt.offset >= len(records) {
+		records = records[:0]
+	} else {
+		records = records[stmt.offset:]
+	}
+	if stmt.limit >= 0 && stmt.limit < len(records) {
+		records = records[:stmt.limit]
+	}
+
+	if stmt.count {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"done":      true,
+			"totalSize": len(records),
+			"records":   []interface{}{},
+		})
+		return
+	}
+
+	results := make([]map[string]interface{}, len(records))
+	name := s.table(stmt.sobject).name
+	for idx, record := range records {
+		results[idx] = project(version, name, record, stmt.fields)
+	}
+
+	s.page(w, version, s.openCursor(results), 0)
+}
+
+// maxCursors is the number of open query cursors.  Like an org, the oldest
+// cursor is removed when a query opens one more.
+const maxCursors = 10
+
+// openCursor stores the query results for the query more requests.  The
+// cursors are numbered in order, so the oldest one has the smallest locator.
+func (s *Server) openCursor(results []map[string]interface{}) string {
+	s.counter++
+	cursor := fmt.Sprintf("01g%015d", s.counter)
+	s.cursors[cursor] = results
+	for len(s.cursors) > maxCursors {
+		oldest := cursor
+		for locator := range s.cursors {
+			if locator < oldest {
+				oldest = locator
+			}
+		}
+		delete(s.cursors, oldest)
+	}
+	return cursor
+}
+
+func (s *Server) queryMore(w http.ResponseWriter, version int, locator string) {
+	idx := strings.LastIndex(locator, "-")
+	if idx < 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY_LOCATOR", "invalid query locator")
+		return
+	}
+	offset, err := strconv.Atoi(locator[idx+1:])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY_LOCATOR", "invalid query locator")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, has := s.cursors[locator[:idx]]; has == false {
+		writeError(w, http.StatusBadRequest, "INVALID_QUERY_LOCATOR", "invalid query locator")
+		return
+	}
+	s.page(w, version, locator[:idx], offset)
+}
+
+// page writes the query response starting at the offset.  The cursor is
+// removed once the last page has been written.
+func (s *Server) page(w http.ResponseWriter, version int, cursor string, offset int) {
+	results := s.cursors[cursor]
+	batchSize := s.QueryBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultQueryBatchSize
+	}
+	end := offset + batchSize
+	if end > len(results) {
+		end = len(results)
+	}
+	if offset > end {
+		offset = end
+	}
+	response := map[string]interface{}{
+		"done":      end == len(results),
+		"totalSize": len(results),
+		"records":   results[offset:end],
+	}
+	if end < len(results) {
+		response["nextRecordsUrl"] = fmt.Sprintf("/services/data/v%d.0/query/%s-%d", version, cursor, end)
+	} else {
+		delete(s.cursors, cursor)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// project returns the record with its attributes and the selected fields.
+// Relationship fields are returned as nested records, or null when the
+// related record is not present.
+func project(version int, sobject string, record map[string]interface{}, fields []string) map[string]interface{} {
+	result := map[string]interface{}{
+		"attributes": attributes(version, sobject, record["Id"].(string)),
+	}
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		current := result
+		related := record
+		for _, part := range parts[:len(parts)-1] {
+			related, _ = lookup(related, part).(map[string]interface{})
+			if related == nil {
+				current[part] = nil
+				break
+			}
+			nested, ok := current[part].(map[string]interface{})
+			if ok == false {
+				nested = map[string]interface{}{
+					"attributes": relatedAttributes(part, related),
+				}
+				current[part] = nested
+			}
+			current = nested
+		}
+		if related == nil {
+			continue
+		}
+		name := parts[len(parts)-1]
+		if existing := fieldName(related, name); existing != "" {
+			name = existing
+		}
+		current[name] = lookup(related, name)
+	}
+	return result
+}
+
+func relatedAttributes(relationship string, related map[string]interface{}) interface{} {
+	if attrs, has := related["attributes"]; has {
+		return attrs
+	}
+	return map[string]string{
+		"type": relationship,
+	}
+}
+
+func parseStatement(soql string) (*statement, error) {
+	tokens, err := tokenize(soql)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		tokens: tokens,
+	}
+	stmt := &statement{
+		limit: -1,
+	}
+
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+	for {
+		if p.keyword("COUNT") {
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			stmt.count = true
+		} else {
+			field, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			stmt.fields = append(stmt.fields, field)
+		}
+		if p.keyword(",") == false {
+			break
+		}
+	}
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	if stmt.sobject, err = p.ident(); err != nil {
+		return nil, err
+	}
+	if p.keyword("WHERE") {
+		if stmt.where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.keyword("ORDER") {
+		if err := p.expect("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			field, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			order := orderField{
+				field: field,
+			}
+			if p.keyword("DESC") {
+				order.descending = true
+			} else {
+				p.keyword("ASC")
+			}
+			if p.keyword("NULLS") {
+				if p.keyword("FIRST") == false && p.keyword("LAST") == false {
+					return nil, p.unexpected()
+				}
+			}
+			stmt.orderBy = append(stmt.orderBy, order)
+			if p.keyword(",") == false {
+				break
+			}
+		}
+	}
+	if p.keyword("LIMIT") {
+		if stmt.limit, err = p.integer(); err != nil {
+			return nil, err
+		}
+	}
+	if p.keyword("OFFSET") {
+		if stmt.offset, err = p.integer(); err != nil {
+			return nil, err
+		}
+	}
+	if p.position < len(p.tokens) {
+		return nil, p.unexpected()
+	}
+	return stmt, nil
+}
+
+func (p *parser) or() (condition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = func(l, r condition) condition {
+			return func(record map[string]interface{}) bool {
+				return l(record) || r(record)
+			}
+		}(left, right)
+	}
+	return left, nil
+}
+
+func (p *parser) and() (condition, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = func(l, r condition) condition {
+			return func(record map[string]interface{}) bool {
+				return l(record) && r(record)
+			}
+		}(left, right)
+	}
+	return left, nil
+}
+
+func (p *parser) not() (condition, error) {
+	if p.keyword("NOT") {
+		operand, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return func(record map[string]interface{}) bool {
+			return operand(record) == false
+		}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (condition, error) {
+	if p.keyword("(") {
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	field, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	negate := p.keyword("NOT")
+
+	var expr condition
+	switch {
+	case p.keyword("IN"):
+		values, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+		expr = func(record map[string]interface{}) bool {
+			actual := lookup(record, field)
+			for _, value := range values {
+				if compare(actual, value) == 0 {
+					return true
+				}
+			}
+			return false
+		}
+	case p.keyword("LIKE"):
+		if p.position >= len(p.tokens) || p.tokens[p.position].kind != stringToken {
+			return nil, errors.New("LIKE requires a string value")
+		}
+		like := likePattern(p.tokens[p.position].text)
+		p.position++
+		expr = func(record map[string]interface{}) bool {
+			actual := lookup(record, field)
+			return actual != nil && like.MatchString(fmt.Sprint(actual))
+		}
+	case negate:
+		return nil, p.unexpected()
+	default:
+		if p.position >= len(p.tokens) || p.tokens[p.position].kind != operatorToken {
+			return nil, p.unexpected()
+		}
+		operator := p.tokens[p.position].text
+		p.position++
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		expr = comparison(field, operator, value)
+	}
+
+	if negate {
+		inner := expr
+		expr = func(record map[string]interface{}) bool {
+			return inner(record) == false
+		}
+	}
+	return expr, nil
+}
+
+func comparison(field, operator string, value interface{}) condition {
+	return func(record map[string]interface{}) bool {
+		actual := lookup(record, field)
+		switch operator {
+		case "=":
+			return compare(actual, value) == 0
+		case "!=", "<>":
+			return compare(actual, value) != 0
+		}
+		if actual == nil || value == nil {
+			return false
+		}
+		result := compare(actual, value)
+		switch operator {
+		case "<":
+			return result < 0
+		case "<=":
+			return result <= 0
+		case ">":
+			return result > 0
+		default:
+			return result >= 0
+		}
+	}
+}
+
+func (p *parser) list() ([]interface{}, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if p.keyword(",") == false {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (p *parser) value() (interface{}, error) {
+	if p.position >= len(p.tokens) {
+		return nil, errors.New("unexpected end of query")
+	}
+	t := p.tokens[p.position]
+	p.position++
+	switch t.kind {
+	case stringToken:
+		return likeEscapes.Replace(t.text), nil
+	case numberToken:
+		if number, err := strconv.ParseFloat(t.text, 64); err == nil {
+			return number, nil
+		}
+		return t.text, nil
+	case identToken:
+		switch strings.ToUpper(t.text) {
+		case "TRUE":
+			return true, nil
+		case "FALSE":
+			return false, nil
+		case "NULL":
+			return nil, nil
+		}
+		return t.text, nil
+	}
+	p.position--
+	return nil, p.unexpected()
+}
+
+func (p *parser) ident() (string, error) {
+	if p.position >= len(p.tokens) || p.tokens[p.position].kind != identToken {
+		return "", p.unexpected()
+	}
+	p.position++
+	return p.tokens[p.position-1].text, nil
+}
+
+func (p *parser) integer() (int, error) {
+	if p.position >= len(p.tokens) || p.tokens[p.position].kind != numberToken {
+		return 0, p.unexpected()
+	}
+	value, err := strconv.Atoi(p.tokens[p.position].text)
+	if err != nil {
+		return 0, p.unexpected()
+	}
+	p.position++
+	return value, nil
+}
+
+// keyword consumes the next token if it is the keyword or punctuation.
+func (p *parser) keyword(keyword string) bool {
+	if p.position >= len(p.tokens) {
+		return false
+	}
+	t := p.tokens[p.position]
+	if (t.kind == identToken || t.kind == punctToken) && strings.EqualFold(t.text, keyword) {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(keyword string) error {
+	if p.keyword(keyword) == false {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.position >= len(p.tokens) {
+		return errors.New("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected token: %s", p.tokens[p.position].text)
+}
+
+func tokenize(soql string) ([]token, error) {
+	var tokens []token
+	runes := []rune(soql)
+	for idx := 0; idx < len(runes); {
+		r := runes[idx]
+		switch {
+		case unicode.IsSpace(r):
+			idx++
+		case r == '\'':
+			var builder strings.Builder
+			idx++
+			for ; idx < len(runes) && runes[idx] != '\''; idx++ {
+				if runes[idx] == '\\' && idx+1 < len(runes) {
+					idx++
+					switch runes[idx] {
+					case 'n':
+						builder.WriteRune('\n')
+					case 't':
+						builder.WriteRune('\t')
+					case 'r':
+						builder.WriteRune('\r')
+					case '\\', '%', '_':
+						// the escapes are kept for the LIKE pattern
+						builder.WriteRune('\\')
+						builder.WriteRune(runes[idx])
+					default:
+						builder.WriteRune(runes[idx])
+					}
+					continue
+				}
+				builder.WriteRune(runes[idx])
+			}
+			if idx >= len(runes) {
+				return nil, errors.New("unterminated string literal")
+			}
+			idx++
+			tokens = append(tokens, token{kind: stringToken, text: builder.String()})
+		case unicode.IsDigit(r) || (r == '-' && idx+1 < len(runes) && unicode.IsDigit(runes[idx+1])):
+			start := idx
+			for idx++; idx < len(runes) && strings.ContainsRune("0123456789.-:+TZ", runes[idx]); idx++ {
+			}
+			tokens = append(tokens, token{kind: numberToken, text: string(runes[start:idx])})
+		case unicode.IsLetter(r) || r == '_':
+			start := idx
+			for ; idx < len(runes) && (unicode.IsLetter(runes[idx]) || unicode.IsDigit(runes[idx]) || runes[idx] == '_' || runes[idx] == '.'); idx++ {
+			}
+			tokens = append(tokens, token{kind: identToken, text: string(runes[start:idx])})
+		case strings.ContainsRune("(),", r):
+			idx++
+			tokens = append(tokens, token{kind: punctToken, text: string(r)})
+		case strings.ContainsRune("=!<>", r):
+			start := idx
+			idx++
+			if idx < len(runes) && (runes[idx] == '=' || (r == '<' && runes[idx] == '>')) {
+				idx++
+			}
+			operator := string(runes[start:idx])
+			if operator == "!" {
+				return nil, errors.New("unexpected token: !")
+			}
+			tokens = append(tokens, token{kind: operatorToken, text: operator})
+		default:
+			return nil, fmt.Errorf("unexpected character: %c", r)
+		}
+	}
+	return tokens, nil
+}
+
+// likeEscapes removes the escapes that the tokenizer keeps for the LIKE
+// pattern from the other string values.
+var likeEscapes = strings.NewReplacer(`\\`, `\`, `\%`, "%", `\_`, "_")
+
+// likePattern returns the regular expression of the LIKE pattern.  The
+// escaped \% and \_ are matched as the literal characters.
+func likePattern(pattern string) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("(?is)^")
+	runes := []rune(pattern)
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case r == '\\' && idx+1 < len(runes):
+			idx++
+			builder.WriteString(regexp.QuoteMeta(string(runes[idx])))
+		case r == '%':
+			builder.WriteString(".*")
+		case r == '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
+
+// compare orders two values.  The values are compared numerically when the
+// first value, which is the field value, is a JSON number and the second value
+// is a number or a numeric string.  Other values are compared as case
+// insensitive strings, so a string field is never compared as a number.  Null
+// values are ordered first.
+func compare(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		if s, is := b.(string); is {
+			var err error
+			y, err = strconv.ParseFloat(s, 64)
+			ok = err == nil
+		}
+		if ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(fmt.Sprint(a)), strings.ToLower(fmt.Sprint(b)))
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
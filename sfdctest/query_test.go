@@ -0,0 +1,271 @@
+package sfdctest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func testAccounts(server *Server) {
+	accounts := []map[string]interface{}{
+		{"Name": "Acme", "Industry": "Banking", "NumberOfEmployees": float64(100), "Active__c": true},
+		{"Name": "Globex", "Industry": "Energy", "NumberOfEmployees": float64(2500), "Active__c": true},
+		{"Name": "Initech", "Industry": "Banking", "NumberOfEmployees": float64(50), "Active__c": false},
+		{"Name": "Umbrella", "Industry": nil, "NumberOfEmployees": float64(9000), "Active__c": true},
+		{"Name": "O'Reilly", "Industry": "Media", "NumberOfEmployees": float64(300), "Active__c": false},
+	}
+	for _, account := range accounts {
+		server.Insert("Account", account)
+	}
+}
+
+func TestServer_Query(t *testing.T) {
+	server, c := testClient(t)
+	testAccounts(server)
+
+	tests := []struct {
+		name    string
+		soql    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "All",
+			soql: "SELECT Name FROM Account",
+			want: []string{"Acme", "Globex", "Initech", "Umbrella", "O'Reilly"},
+		},
+		{
+			name: "Equals",
+			soql: "SELECT Name FROM Account WHERE Industry = 'banking'",
+			want: []string{"Acme", "Initech"},
+		},
+		{
+			name: "Numbers",
+			soql: "SELECT Name FROM Account WHERE NumberOfEmployees >= 300 AND NumberOfEmployees < 9000",
+			want: []string{"Globex", "O'Reilly"},
+		},
+		{
+			name: "Or And Not",
+			soql: "select Name from Account where (Industry = 'Energy' or Industry = 'Media') and not Active__c = false",
+			want: []string{"Globex"},
+		},
+		{
+			name: "Null",
+			soql: "SELECT Name FROM Account WHERE Industry = null",
+			want: []string{"Umbrella"},
+		},
+		{
+			name: "Like",
+			soql: "SELECT Name FROM Account WHERE Name LIKE '%e%' AND Name NOT LIKE 'G%'",
+			want: []string{"Acme", "Initech", "Umbrella", "O'Reilly"},
+		},
+		{
+			name: "In",
+			soql: "SELECT Name FROM Account WHERE Industry IN ('Energy', 'Media') OR Name NOT IN ('Acme', 'Globex', 'Initech', 'O\\'Reilly')",
+			want: []string{"Globex", "Umbrella", "O'Reilly"},
+		},
+		{
+			name: "Order By Limit Offset",
+			soql: "SELECT Name FROM Account ORDER BY NumberOfEmployees DESC LIMIT 2 OFFSET 1",
+			want: []string{"Globex", "O'Reilly"},
+		},
+		{
+			name:    "Malformed",
+			soql:    "SELECT FROM Account",
+			wantErr: true,
+		},
+	}
+	resource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resource.Query(testSOQL(tt.soql), false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resource.Query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if errors.Is(err, sfdc.ErrMalformedQuery) == false {
+					t.Errorf("Resource.Query() error = %v, want %v", err, sfdc.ErrMalformedQuery)
+				}
+				return
+			}
+			got := []string{}
+			for _, record := range result.Records() {
+				name, _ := record.Record().FieldValue("Name")
+				got = append(got, name.(string))
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Resource.Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_Query_Paging(t *testing.T) {
+	server, c := testClient(t)
+	testAccounts(server)
+	server.QueryBatchSize = 2
+
+	resource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	result, err := resource.Query(testSOQL("SELECT Name FROM Account"), false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	if result.TotalSize() != 5 {
+		t.Errorf("Resource.Query() total size = %d, want 5", result.TotalSize())
+	}
+
+	pages := 1
+	count := len(result.Records())
+	for result.MoreRecords() {
+		result, err = result.Next()
+		if err != nil {
+			t.Fatalf("QueryResult.Next() error = %v", err)
+		}
+		pages++
+		count += len(result.Records())
+	}
+	if pages != 3 || count != 5 {
+		t.Errorf("QueryResult.Next() pages = %d records = %d, want 3 and 5", pages, count)
+	}
+	if len(server.cursors) != 0 {
+		t.Errorf("QueryResult.Next() open cursors = %d, want 0", len(server.cursors))
+	}
+
+	first, err := resource.Query(testSOQL("SELECT Name FROM Account"), false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	for idx := 0; idx < maxCursors; idx++ {
+		if _, err := resource.Query(testSOQL("SELECT Name FROM Account"), false); err != nil {
+			t.Fatalf("Resource.Query() error = %v", err)
+		}
+	}
+	if len(server.cursors) != maxCursors {
+		t.Errorf("Resource.Query() open cursors = %d, want %d", len(server.cursors), maxCursors)
+	}
+	if _, err := first.Next(); err == nil {
+		t.Error("QueryResult.Next() expected an error for the oldest cursor")
+	}
+}
+
+func TestServer_Query_Literals(t *testing.T) {
+	server, c := testClient(t)
+	accounts := []map[string]interface{}{
+		{"Name": "100% Cotton", "AccountNumber": "10.0"},
+		{"Name": "1000 Cotton", "AccountNumber": "10"},
+		{"Name": "A_B", "AccountNumber": "010"},
+		{"Name": "AxB", "AccountNumber": nil},
+	}
+	for _, account := range accounts {
+		server.Insert("Account", account)
+	}
+
+	tests := []struct {
+		name string
+		soql string
+		want []string
+	}{
+		{
+			name: "Like Escaped Percent",
+			soql: "SELECT Name FROM Account WHERE Name LIKE '100\\%%'",
+			want: []string{"100% Cotton"},
+		},
+		{
+			name: "Like Escaped Underscore",
+			soql: "SELECT Name FROM Account WHERE Name LIKE 'A\\_B'",
+			want: []string{"A_B"},
+		},
+		{
+			name: "Like Wildcards",
+			soql: "SELECT Name FROM Account WHERE Name LIKE 'A_B' OR Name LIKE '100%'",
+			want: []string{"100% Cotton", "1000 Cotton", "A_B", "AxB"},
+		},
+		{
+			name: "Equals Escaped",
+			soql: "SELECT Name FROM Account WHERE Name = 'A\\_B'",
+			want: []string{"A_B"},
+		},
+		{
+			name: "Numeric String",
+			soql: "SELECT Name FROM Account WHERE AccountNumber = '10'",
+			want: []string{"1000 Cotton"},
+		},
+	}
+	resource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resource.Query(testSOQL(tt.soql), false)
+			if err != nil {
+				t.Fatalf("Resource.Query() error = %v", err)
+			}
+			got := []string{}
+			for _, record := range result.Records() {
+				name, _ := record.Record().FieldValue("Name")
+				got = append(got, name.(string))
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Resource.Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if len(server.cursors) != 0 {
+		t.Errorf("Resource.Query() open cursors = %d, want 0", len(server.cursors))
+	}
+}
+
+func TestServer_Query_Count(t *testing.T) {
+	server, c := testClient(t)
+	testAccounts(server)
+
+	resource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	result, err := resource.Query(testSOQL("SELECT COUNT() FROM Account WHERE Active__c = true"), false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	if result.TotalSize() != 3 || len(result.Records()) != 0 {
+		t.Errorf("Resource.Query() total size = %d records = %d", result.TotalSize(), len(result.Records()))
+	}
+}
+
+func TestServer_Query_Relationship(t *testing.T) {
+	server, c := testClient(t)
+	server.Insert("Contact", map[string]interface{}{
+		"LastName": "Smith",
+		"Account": map[string]interface{}{
+			"Name": "Acme",
+		},
+	})
+
+	resource, err := c.SOQL()
+	if err != nil {
+		t.Fatalf("Client.SOQL() error = %v", err)
+	}
+	result, err := resource.Query(testSOQL("SELECT LastName, Account.Name FROM Contact WHERE Account.Name = 'Acme'"), false)
+	if err != nil {
+		t.Fatalf("Resource.Query() error = %v", err)
+	}
+	if len(result.Records()) != 1 {
+		t.Fatalf("Resource.Query() records = %d, want 1", len(result.Records()))
+	}
+	account, has := result.Records()[0].Record().LookUp("Account")
+	if has == false {
+		t.Fatal("Resource.Query() did not return the Account relationship")
+	}
+	if name, _ := account.FieldValue("Name"); name != "Acme" {
+		t.Errorf("Resource.Query() Account.Name = %v, want Acme", name)
+	}
+}
@@ -0,0 +1,300 @@
+// Package sfdctest provides an in-memory fake Salesforce org for tests.
+package sfdctest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/credentials"
+	"github.com/g8rswimmer/go-sfdc/session"
+)
+
+const (
+	// DefaultVersion is the API version of the server's configuration.
+	DefaultVersion = 45
+	// DefaultQueryBatchSize is the number of records returned in each query page.
+	DefaultQueryBatchSize = 2000
+	// DailyAPILimit is the daily API request limit of the fake org.
+	DailyAPILimit = 15000
+	// ClientSecret is the client secret of the server's configuration.  The
+	// token signatures are signed with it.
+	ClientSecret = "sfdctest"
+)
+
+var versionPath = regexp.MustCompile(`^/services/data/v(\d+)\.0(/.*)?$`)
+
+// Server is an in-memory fake Salesforce org served by an httptest server.  It
+// supports OAuth token issuance, SObject CRUD, simple SOQL queries with paging,
+// the composite, composite batch and collections APIs and Bulk 2.0 ingest jobs.
+//
+// Version is the API version of the configuration.  This field is optional.
+//
+// QueryBatchSize is the number of records returned in each query page.  This
+// field is optional.
+type Server struct {
+	Version        int
+	QueryBatchSize int
+
+	server   *httptest.Server
+	mutex    sync.Mutex
+	tokens   map[string]struct{}
+	tables   map[string]*table
+	cursors  map[string][]map[string]interface{}
+	jobs     map[string]*job
+	jobOrder []string
+	apiCalls int
+	counter  int
+}
+
+// NewServer starts the fake Salesforce org.  The server should be closed
+// when the test is done.
+func NewServer() *Server {
+	s := &Server{
+		Version:        DefaultVersion,
+		QueryBatchSize: DefaultQueryBatchSize,
+		tokens:         make(map[string]struct{}),
+		tables:         make(map[string]*table),
+		cursors:        make(map[string][]map[string]interface{}),
+		jobs:           make(map[string]*job),
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// Close will shut down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the login and instance URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Client returns the HTTP client for the server.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// Configuration returns a configuration with password credentials for the server.
+func (s *Server) Configuration() sfdc.Configuration {
+	creds, _ := credentials.NewPasswordCredentials(credentials.PasswordCredentials{
+		URL:          s.URL(),
+		Username:     "user@sfdctest.example.com",
+		Password:     "password",
+		ClientID:     "sfdctest",
+		ClientSecret: ClientSecret,
+	})
+	return sfdc.Configuration{
+		Credentials: creds,
+		Client:      s.Client(),
+		Version:     s.Version,
+	}
+}
+
+// Session will open a session with the server's configuration.
+func (s *Server) Session() (*session.Session, error) {
+	return session.Open(s.Configuration())
+}
+
+// ExpireTokens will expire all of the issued access tokens, so that the next
+// API request is unauthorized.
+func (s *Server) ExpireTokens() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tokens = make(map[string]struct{})
+}
+
+// APICalls returns the number of API requests that the server has handled.
+func (s *Server) APICalls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.apiCalls
+}
+
+// ServeHTTP handles the Salesforce API requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/services/oauth2/token":
+		s.token(w, r)
+		return
+	case "/services/oauth2/revoke":
+		s.revoke(w, r)
+		return
+	case "/services/data", "/services/data/":
+		s.versions(w, r)
+		return
+	}
+
+	if s.authorized(r) == false {
+		writeError(w, http.StatusUnauthorized, "INVALID_SESSION_ID", "Session expired or invalid")
+		return
+	}
+
+	matches := versionPath.FindStringSubmatch(r.URL.Path)
+	if matches == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+		return
+	}
+
+	s.mutex.Lock()
+	s.apiCalls++
+	w.Header().Set("Sforce-Limit-Info", fmt.Sprintf("api-usage=%d/%d", s.apiCalls, DailyAPILimit))
+	s.mutex.Unlock()
+
+	version, _ := strconv.Atoi(matches[1])
+	s.route(w, r, version, matches[2])
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, version int, resource string) {
+	switch {
+	case resource == "" || resource == "/":
+		s.resources(w, version)
+	case resource == "/limits" || resource == "/limits/":
+		s.limits(w)
+	case strings.HasPrefix(resource, "/sobjects/"):
+		s.sobjects(w, r, version, strings.TrimPrefix(resource, "/sobjects/"))
+	case resource == "/query" || resource == "/query/" || resource == "/queryAll" || resource == "/queryAll/":
+		s.query(w, r, version)
+	case strings.HasPrefix(resource, "/query/"):
+		s.queryMore(w, version, strings.TrimPrefix(resource, "/query/"))
+	case resource == "/composite" || resource == "/composite/":
+		s.composite(w, r, version)
+	case resource == "/composite/batch" || resource == "/composite/batch/":
+		s.batch(w, r)
+	case resource == "/composite/sobjects" || strings.HasPrefix(resource, "/composite/sobjects/"):
+		s.collections(w, r, version, strings.Trim(strings.TrimPrefix(resource, "/composite/sobjects"), "/"))
+	case resource == "/jobs/ingest" || strings.HasPrefix(resource, "/jobs/ingest/"):
+		s.ingest(w, r, version, strings.Trim(strings.TrimPrefix(resource, "/jobs/ingest"), "/"))
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{
+			"error":             "invalid_request",
+			"error_description": "must use HTTP POST",
+		})
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":             "unsupported_grant_type",
+			"error_description": "grant type not supported",
+		})
+		return
+	}
+
+	token := "00Dsfdctest!" + randomHex()
+	s.mutex.Lock()
+	s.tokens[token] = struct{}{}
+	s.mutex.Unlock()
+
+	id := s.URL() + "/id/00D000000000001AAA/005000000000001AAA"
+	issuedAt := "1553568410028"
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": token,
+		"instance_url": s.URL(),
+		"id":           id,
+		"token_type":   "Bearer",
+		"issued_at":    issuedAt,
+		"signature":    signature(id, issuedAt),
+	})
+}
+
+// signature is the base64 HMAC-SHA256 of the identity URL and the issued
+// time, keyed with the client secret, like the Salesforce token signature.
+func signature(id, issuedAt string) string {
+	mac := hmac.New(sha256.New, []byte(ClientSecret))
+	mac.Write([]byte(id + issuedAt))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) revoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err == nil {
+		s.mutex.Lock()
+		delete(s.tokens, r.Form.Get("token"))
+		s.mutex.Unlock()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, has := s.tokens[token]
+	return has
+}
+
+func (s *Server) versions(w http.ResponseWriter, r *http.Request) {
+	versions := make([]map[string]string, 0, s.Version)
+	for version := 20; version <= s.Version; version++ {
+		versions = append(versions, map[string]string{
+			"label":   fmt.Sprintf("Version %d", version),
+			"url":     fmt.Sprintf("/services/data/v%d.0", version),
+			"version": fmt.Sprintf("%d.0", version),
+		})
+	}
+	writeJSON(w, http.StatusOK, versions)
+}
+
+func (s *Server) resources(w http.ResponseWriter, version int) {
+	base := fmt.Sprintf("/services/data/v%d.0", version)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"composite": base + "/composite",
+		"jobs":      base + "/jobs",
+		"limits":    base + "/limits",
+		"query":     base + "/query",
+		"queryAll":  base + "/queryAll",
+		"sobjects":  base + "/sobjects",
+	})
+}
+
+func (s *Server) limits(w http.ResponseWriter) {
+	s.mutex.Lock()
+	remaining := DailyAPILimit - s.apiCalls
+	s.mutex.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"DailyApiRequests": map[string]int{
+			"Max":       DailyAPILimit,
+			"Remaining": remaining,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, []sfdc.Error{
+		{
+			ErrorCode: code,
+			Message:   message,
+			Fields:    []string{},
+		},
+	})
+}
+
+func randomHex() string {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
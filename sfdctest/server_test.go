@@ -0,0 +1,152 @@
+package sfdctest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+	"github.com/g8rswimmer/go-sfdc/client"
+)
+
+type testRecord struct {
+	sobject  string
+	id       string
+	external string
+	fields   map[string]interface{}
+}
+
+func (r *testRecord) SObject() string {
+	return r.sobject
+}
+func (r *testRecord) ID() string {
+	return r.id
+}
+func (r *testRecord) ExternalField() string {
+	return r.external
+}
+func (r *testRecord) Fields() map[string]interface{} {
+	return r.fields
+}
+
+type testQuerier struct {
+	sobject  string
+	id       string
+	external string
+	fields   []string
+}
+
+func (q *testQuerier) SObject() string {
+	return q.sobject
+}
+func (q *testQuerier) ID() string {
+	return q.id
+}
+func (q *testQuerier) ExternalField() string {
+	return q.external
+}
+func (q *testQuerier) Fields() []string {
+	return q.fields
+}
+
+type testSOQL string
+
+func (q testSOQL) Format() (string, error) {
+	return string(q), nil
+}
+
+func testClient(t *testing.T) (*Server, *client.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.Configuration())
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	return server, c
+}
+
+func TestServer_Session(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	session, err := server.Session()
+	if err != nil {
+		t.Fatalf("Server.Session() error = %v", err)
+	}
+	if session.InstanceURL() != server.URL() {
+		t.Errorf("Server.Session() instance URL = %s, want %s", session.InstanceURL(), server.URL())
+	}
+	if session.ServiceURL() != server.URL()+"/services/data/v45.0" {
+		t.Errorf("Server.Session() service URL = %s", session.ServiceURL())
+	}
+	if err := session.VerifySignature(ClientSecret); err != nil {
+		t.Errorf("Session.VerifySignature() error = %v", err)
+	}
+	if err := session.VerifySignature("not the secret"); err == nil {
+		t.Errorf("Session.VerifySignature() expected an error for the wrong secret")
+	}
+}
+
+func TestServer_ExpireTokens(t *testing.T) {
+	server, c := testClient(t)
+	id := server.Insert("Account", map[string]interface{}{"Name": "Test"})
+
+	server.ExpireTokens()
+
+	// the session refreshes the expired token and retries the request
+	resource, err := c.SObject()
+	if err != nil {
+		t.Fatalf("Client.SObject() error = %v", err)
+	}
+	record, err := resource.Query(&testQuerier{sobject: "Account", id: id})
+	if err != nil {
+		t.Fatalf("Resources.Query() error = %v", err)
+	}
+	if recordID(record) != id {
+		t.Errorf("Resources.Query() ID = %s, want %s", recordID(record), id)
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL() + "/services/data/v45.0/limits")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer response.Body.Close()
+
+	err = sfdc.NewAPIError(response)
+	if errors.Is(err, sfdc.ErrInvalidSessionID) == false {
+		t.Errorf("Server unauthorized error = %v, want %v", err, sfdc.ErrInvalidSessionID)
+	}
+}
+
+func TestServer_Limits(t *testing.T) {
+	server, c := testClient(t)
+
+	resource, err := c.Limits()
+	if err != nil {
+		t.Fatalf("Client.Limits() error = %v", err)
+	}
+	if _, err := resource.Retrieve(); err != nil {
+		t.Fatalf("limits.Resource.Retrieve() error = %v", err)
+	}
+	value, err := resource.Retrieve()
+	if err != nil {
+		t.Fatalf("limits.Resource.Retrieve() error = %v", err)
+	}
+	if value.DailyApiRequests.Max != DailyAPILimit {
+		t.Errorf("limits.Resource.Retrieve() max = %d, want %d", value.DailyApiRequests.Max, DailyAPILimit)
+	}
+	if value.DailyApiRequests.Remaining != DailyAPILimit-server.APICalls() {
+		t.Errorf("limits.Resource.Retrieve() remaining = %d, want %d", value.DailyApiRequests.Remaining, DailyAPILimit-server.APICalls())
+	}
+}
+
+func recordID(record *sfdc.Record) string {
+	id, _ := record.FieldValue("Id")
+	value, _ := id.(string)
+	return value
+}
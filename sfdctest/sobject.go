@@ -0,0 +1,346 @@
+package sfdctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var keyPrefixes = map[string]string{
+	"account":     "001",
+	"contact":     "003",
+	"user":        "005",
+	"opportunity": "006",
+	"lead":        "00Q",
+	"case":        "500",
+}
+
+type table struct {
+	name    string
+	prefix  string
+	order   []string
+	records map[string]map[string]interface{}
+}
+
+// Insert will store a record and returns the generated Salesforce ID.  This
+// can be used to seed the org before the test.
+func (s *Server) Insert(sobject string, fields map[string]interface{}) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.insert(sobject, fields)
+}
+
+// Record returns a copy of the stored record, which can be used to verify the
+// test.  The record is nil if it does not exist.
+func (s *Server) Record(sobject, id string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	record := s.find(sobject, id)
+	if record == nil {
+		return nil
+	}
+	return copyRecord(record)
+}
+
+// Records returns copies of the stored records of the SObject in insert order.
+func (s *Server) Records(sobject string) []map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	records := []map[string]interface{}{}
+	for _, record := range s.table(sobject).list() {
+		records = append(records, copyRecord(record))
+	}
+	return records
+}
+
+func (s *Server) table(sobject string) *table {
+	key := strings.ToLower(sobject)
+	if t, has := s.tables[key]; has {
+		return t
+	}
+	prefix, has := keyPrefixes[key]
+	if has == false {
+		prefix = fmt.Sprintf("a%02X", len(s.tables)+1)
+	}
+	t := &table{
+		name:    sobject,
+		prefix:  prefix,
+		records: make(map[string]map[string]interface{}),
+	}
+	s.tables[key] = t
+	return t
+}
+
+func (s *Server) insert(sobject string, fields map[string]interface{}) string {
+	t := s.table(sobject)
+	s.counter++
+	id := fmt.Sprintf("%s%015d", t.prefix, s.counter)
+	record := map[string]interface{}{}
+	for field, value := range fields {
+		if strings.EqualFold(field, "Id") || field == "attributes" {
+			continue
+		}
+		record[field] = value
+	}
+	record["Id"] = id
+	t.order = append(t.order, id)
+	t.records[id] = record
+	return id
+}
+
+func (s *Server) find(sobject, id string) map[string]interface{} {
+	return s.table(sobject).records[id]
+}
+
+func (s *Server) findByField(sobject, field string, value interface{}) map[string]interface{} {
+	for _, record := range s.table(sobject).list() {
+		if compare(lookup(record, field), value) == 0 {
+			return record
+		}
+	}
+	return nil
+}
+
+func (s *Server) update(record map[string]interface{}, fields map[string]interface{}) {
+	for field, value := range fields {
+		if strings.EqualFold(field, "Id") || field == "attributes" {
+			continue
+		}
+		if existing := fieldName(record, field); existing != "" {
+			field = existing
+		}
+		record[field] = value
+	}
+}
+
+func (s *Server) remove(sobject, id string) bool {
+	t := s.table(sobject)
+	if _, has := t.records[id]; has == false {
+		return false
+	}
+	delete(t.records, id)
+	for idx, recordID := range t.order {
+		if recordID == id {
+			t.order = append(t.order[:idx], t.order[idx+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// sobjectOf finds the SObject of the stored record.
+func (s *Server) sobjectOf(id string) (string, bool) {
+	for _, t := range s.tables {
+		if _, has := t.records[id]; has {
+			return t.name, true
+		}
+	}
+	return "", false
+}
+
+func (t *table) list() []map[string]interface{} {
+	records := make([]map[string]interface{}, 0, len(t.order))
+	for _, id := range t.order {
+		records = append(records, t.records[id])
+	}
+	return records
+}
+
+func (s *Server) sobjects(w http.ResponseWriter, r *http.Request, version int, resource string) {
+	parts := strings.Split(strings.Trim(resource, "/"), "/")
+
+	switch len(parts) {
+	case 1:
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+			return
+		}
+		fields, ok := decodeFields(w, r)
+		if ok == false {
+			return
+		}
+		s.mutex.Lock()
+		id := s.insert(parts[0], fields)
+		s.mutex.Unlock()
+		writeJSON(w, http.StatusCreated, saveResult(id, true))
+	case 2:
+		s.record(w, r, version, parts[0], parts[1])
+	case 3:
+		s.external(w, r, version, parts[0], parts[1], parts[2])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+	}
+}
+
+func (s *Server) record(w http.ResponseWriter, r *http.Request, version int, sobject, id string) {
+	var fields map[string]interface{}
+	if r.Method == http.MethodPatch {
+		var ok bool
+		if fields, ok = decodeFields(w, r); ok == false {
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.find(sobject, id)
+	if record == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.selectFields(version, sobject, record, r.URL.Query().Get("fields")))
+	case http.MethodPatch:
+		s.update(record, fields)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		s.remove(sobject, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+	}
+}
+
+func (s *Server) external(w http.ResponseWriter, r *http.Request, version int, sobject, field, value string) {
+	var fields map[string]interface{}
+	if r.Method == http.MethodPatch {
+		var ok bool
+		if fields, ok = decodeFields(w, r); ok == false {
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.findByField(sobject, field, value)
+
+	switch r.Method {
+	case http.MethodGet:
+		if record == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.selectFields(version, sobject, record, r.URL.Query().Get("fields")))
+	case http.MethodPatch:
+		if record != nil {
+			s.update(record, fields)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fields[field] = value
+		id := s.insert(sobject, fields)
+		result := saveResult(id, true)
+		result["created"] = true
+		writeJSON(w, http.StatusCreated, result)
+	case http.MethodDelete:
+		if record == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "The requested resource does not exist")
+			return
+		}
+		s.remove(sobject, record["Id"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "HTTP Method '"+r.Method+"' not allowed")
+	}
+}
+
+// selectFields returns the record with its attributes, ID and the requested
+// fields.  All of the fields are returned when none are requested.
+func (s *Server) selectFields(version int, sobject string, record map[string]interface{}, fields string) map[string]interface{} {
+	result := map[string]interface{}{
+		"attributes": attributes(version, s.table(sobject).name, record["Id"].(string)),
+		"Id":         record["Id"],
+	}
+	if fields == "" {
+		for field, value := range record {
+			result[field] = value
+		}
+		return result
+	}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		result[field] = lookup(record, field)
+	}
+	return result
+}
+
+func attributes(version int, sobject, id string) map[string]string {
+	return map[string]string{
+		"type": sobject,
+		"url":  fmt.Sprintf("/services/data/v%d.0/sobjects/%s/%s", version, sobject, id),
+	}
+}
+
+func saveResult(id string, success bool, errs ...map[string]interface{}) map[string]interface{} {
+	if errs == nil {
+		errs = []map[string]interface{}{}
+	}
+	result := map[string]interface{}{
+		"success": success,
+		"errors":  errs,
+	}
+	if id != "" {
+		result["id"] = id
+	}
+	return result
+}
+
+func recordError(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"statusCode": code,
+		"message":    message,
+		"fields":     []string{},
+	}
+}
+
+func decodeFields(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	fields := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON_PARSER_ERROR", err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// lookup returns the value of the field, ignoring case.  Relationship fields
+// are followed through nested records.
+func lookup(record map[string]interface{}, field string) interface{} {
+	parts := strings.SplitN(field, ".", 2)
+	name := fieldName(record, parts[0])
+	if name == "" {
+		return nil
+	}
+	value := record[name]
+	if len(parts) == 1 {
+		return value
+	}
+	if related, ok := value.(map[string]interface{}); ok {
+		return lookup(related, parts[1])
+	}
+	return nil
+}
+
+func fieldName(record map[string]interface{}, field string) string {
+	if _, has := record[field]; has {
+		return field
+	}
+	for name := range record {
+		if strings.EqualFold(name, field) {
+			return name
+		}
+	}
+	return ""
+}
+
+func copyRecord(record map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(record))
+	for field, value := range record {
+		copied[field] = value
+	}
+	return copied
+}
@@ -0,0 +1,126 @@
+package sfdctest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+func TestServer_SObject(t *testing.T) {
+	server, c := testClient(t)
+	resources, err := c.SObject()
+	if err != nil {
+		t.Fatalf("Client.SObject() error = %v", err)
+	}
+
+	inserted, err := resources.Insert(&testRecord{
+		sobject: "Account",
+		fields: map[string]interface{}{
+			"Name":     "Test Account",
+			"Industry": "Banking",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resources.Insert() error = %v", err)
+	}
+	if inserted.Success == false || len(inserted.ID) != 18 || inserted.ID[:3] != "001" {
+		t.Fatalf("Resources.Insert() = %+v", inserted)
+	}
+
+	record, err := resources.Query(&testQuerier{
+		sobject: "Account",
+		id:      inserted.ID,
+		fields:  []string{"Name"},
+	})
+	if err != nil {
+		t.Fatalf("Resources.Query() error = %v", err)
+	}
+	if record.SObject() != "Account" || recordID(record) != inserted.ID {
+		t.Errorf("Resources.Query() = %s %s", record.SObject(), recordID(record))
+	}
+	if name, _ := record.FieldValue("Name"); name != "Test Account" {
+		t.Errorf("Resources.Query() Name = %v", name)
+	}
+	if _, has := record.FieldValue("Industry"); has {
+		t.Error("Resources.Query() returned a field that was not requested")
+	}
+
+	err = resources.Update(&testRecord{
+		sobject: "Account",
+		id:      inserted.ID,
+		fields: map[string]interface{}{
+			"Industry": "Energy",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Resources.Update() error = %v", err)
+	}
+	if industry := server.Record("Account", inserted.ID)["Industry"]; industry != "Energy" {
+		t.Errorf("Resources.Update() Industry = %v, want Energy", industry)
+	}
+
+	if err := resources.Delete(&testRecord{sobject: "Account", id: inserted.ID}); err != nil {
+		t.Fatalf("Resources.Delete() error = %v", err)
+	}
+	if server.Record("Account", inserted.ID) != nil {
+		t.Error("Resources.Delete() did not delete the record")
+	}
+
+	_, err = resources.Query(&testQuerier{sobject: "Account", id: inserted.ID})
+	if errors.Is(err, sfdc.ErrNotFound) == false {
+		t.Errorf("Resources.Query() error = %v, want %v", err, sfdc.ErrNotFound)
+	}
+}
+
+func TestServer_SObject_Upsert(t *testing.T) {
+	server, c := testClient(t)
+	resources, err := c.SObject()
+	if err != nil {
+		t.Fatalf("Client.SObject() error = %v", err)
+	}
+
+	upserter := &testRecord{
+		sobject:  "Custom__c",
+		id:       "ext-1",
+		external: "External__c",
+		fields: map[string]interface{}{
+			"Name": "First",
+		},
+	}
+	value, err := resources.Upsert(upserter)
+	if err != nil {
+		t.Fatalf("Resources.Upsert() error = %v", err)
+	}
+	if value.Inserted == false {
+		t.Fatal("Resources.Upsert() did not insert the record")
+	}
+
+	upserter.fields = map[string]interface{}{
+		"Name": "Second",
+	}
+	value, err = resources.Upsert(upserter)
+	if err != nil {
+		t.Fatalf("Resources.Upsert() error = %v", err)
+	}
+	if value.Inserted {
+		t.Error("Resources.Upsert() inserted an existing record")
+	}
+
+	records := server.Records("Custom__c")
+	if len(records) != 1 || records[0]["Name"] != "Second" || records[0]["External__c"] != "ext-1" {
+		t.Errorf("Resources.Upsert() records = %v", records)
+	}
+
+	record, err := resources.ExternalQuery(&testQuerier{
+		sobject:  "Custom__c",
+		id:       "ext-1",
+		external: "External__c",
+	})
+	if err != nil {
+		t.Fatalf("Resources.ExternalQuery() error = %v", err)
+	}
+	if recordID(record) != records[0]["Id"] {
+		t.Errorf("Resources.ExternalQuery() ID = %s, want %v", recordID(record), records[0]["Id"])
+	}
+}
@@ -3,6 +3,7 @@ package collections
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -68,42 +69,66 @@ func NewResources(session session.ServiceFormatter) (*Resource, error) {
 // Insert will create a group of records in the Salesforce org.  The records do not need to be
 // the same SObject.  It is the responsibility of the caller to properly chunck the records.
 func (r *Resource) Insert(allOrNone bool, records []sobject.Inserter) ([]sobject.InsertValue, error) {
+	return r.InsertWithContext(context.Background(), allOrNone, records)
+}
+
+// InsertWithContext will create a group of records in the Salesforce org using the context
+// for the request.
+func (r *Resource) InsertWithContext(ctx context.Context, allOrNone bool, records []sobject.Inserter) ([]sobject.InsertValue, error) {
 	if r.insert == nil {
 		return nil, errors.New("collections resource: collections may not have been initialized properly")
 	}
 	if records == nil {
 		return nil, errors.New("collections resource: insert records can not be nil")
 	}
-	return r.insert.callout(allOrNone, records)
+	return r.insert.callout(ctx, allOrNone, records)
 }
 
 // Delete will remove a group of records in the Salesforce org.  The records do not need to
 // be the same SObject.
 func (r *Resource) Delete(allOrNone bool, records []string) ([]DeleteValue, error) {
+	return r.DeleteWithContext(context.Background(), allOrNone, records)
+}
+
+// DeleteWithContext will remove a group of records in the Salesforce org using the context
+// for the request.
+func (r *Resource) DeleteWithContext(ctx context.Context, allOrNone bool, records []string) ([]DeleteValue, error) {
 	if r.remove == nil {
 		return nil, errors.New("collections resource: collections may not have been initialized properly")
 	}
 	if records == nil {
 		return nil, errors.New("collections resource: delete records can not be nil")
 	}
-	return r.remove.callout(allOrNone, records)
+	return r.remove.callout(ctx, allOrNone, records)
 }
 
 // Update will update a group of records in the Salesforce org.  The records do not need to be
 // the same SObject.  It is the responsibility of the caller to properly chunck the records.
 func (r *Resource) Update(allOrNone bool, records []sobject.Updater) ([]UpdateValue, error) {
+	return r.UpdateWithContext(context.Background(), allOrNone, records)
+}
+
+// UpdateWithContext will update a group of records in the Salesforce org using the context
+// for the request.
+func (r *Resource) UpdateWithContext(ctx context.Context, allOrNone bool, records []sobject.Updater) ([]UpdateValue, error) {
 	if r.update == nil {
 		return nil, errors.New("collections resource: collections may not have been initialized properly")
 	}
 	if records == nil {
 		return nil, errors.New("collections resource: update records can not be nil")
 	}
-	return r.update.callout(allOrNone, records)
+	return r.update.callout(ctx, allOrNone, records)
 }
 
 // Query will retrieve a group of records from the Salesforce org.  The records to retrieve must
 // be the same SObject.
 func (r *Resource) Query(sobject string, records []sobject.Querier) ([]*sfdc.Record, error) {
+	return r.QueryWithContext(context.Background(), sobject, records)
+}
+
+// QueryWithContext will retrieve a group of records from the Salesforce org using the context
+// for the request.
+func (r *Resource) QueryWithContext(ctx context.Context, sobject string, records []sobject.Querier) ([]*sfdc.Record, error) {
 	if r.query == nil {
 		return nil, errors.New("collections resource: collections may not have been initialized properly")
 	}
@@ -120,15 +145,15 @@ func (r *Resource) Query(sobject string, records []sobject.Querier) ([]*sfdc.Rec
 		return nil, fmt.Errorf("collection resource: %s is not a valid sobject", sobject)
 	}
 
-	return r.query.callout(sobject, records)
+	return r.query.callout(ctx, sobject, records)
 }
 
-func (c *collection) send(session session.ServiceFormatter, value interface{}) error {
+func (c *collection) send(ctx context.Context, session session.ServiceFormatter, value interface{}) error {
 	collectionURL := session.ServiceURL() + c.endpoint
 	if c.values != nil {
 		collectionURL += "?" + c.values.Encode()
 	}
-	request, err := http.NewRequest(c.method, collectionURL, c.body)
+	request, err := http.NewRequestWithContext(ctx, c.method, collectionURL, c.body)
 	if err != nil {
 		return err
 	}
@@ -148,17 +173,7 @@ func (c *collection) send(session session.ServiceFormatter, value interface{}) e
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var insertErrs []sfdc.Error
-		err = decoder.Decode(&insertErrs)
-		var errMsg error
-		if err == nil {
-			for _, insertErr := range insertErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", insertErr.ErrorCode, insertErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-		return errMsg
+		return sfdc.NewAPIError(response)
 	}
 	err = decoder.Decode(value)
 	if err != nil {
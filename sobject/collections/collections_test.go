@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -186,7 +187,7 @@ func Test_collection_send(t *testing.T) {
 				values:   tt.fields.values,
 				body:     tt.fields.body,
 			}
-			if err := c.send(tt.args.session, tt.args.value); (err != nil) != tt.wantErr {
+			if err := c.send(context.Background(), tt.args.session, tt.args.value); (err != nil) != tt.wantErr {
 				t.Errorf("collection.send() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1,8 +1,8 @@
 package sobject
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/g8rswimmer/go-sfdc"
@@ -169,9 +169,9 @@ type describe struct {
 	session session.ServiceFormatter
 }
 
-func (d *describe) callout(sobject string) (DescribeValue, error) {
+func (d *describe) callout(ctx context.Context, sobject string) (DescribeValue, error) {
 
-	request, err := d.request(sobject)
+	request, err := d.request(ctx, sobject)
 
 	if err != nil {
 		return DescribeValue{}, err
@@ -186,10 +186,10 @@ func (d *describe) callout(sobject string) (DescribeValue, error) {
 	return value, nil
 }
 
-func (d *describe) request(sobject string) (*http.Request, error) {
+func (d *describe) request(ctx context.Context, sobject string) (*http.Request, error) {
 	url := d.session.ServiceURL() + objectEndpoint + sobject + describeEndpoint
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	if err != nil {
 		return nil, err
@@ -212,18 +212,7 @@ func (d *describe) response(request *http.Request) (DescribeValue, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var respErrs []sfdc.Error
-		err = decoder.Decode(&respErrs)
-		var errMsg error
-		if err == nil {
-			for _, respErr := range respErrs {
-				errMsg = fmt.Errorf("metadata response err: %s: %s", respErr.ErrorCode, respErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("metadata response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return DescribeValue{}, errMsg
+		return DescribeValue{}, sfdc.NewAPIError(response)
 	}
 
 	var value DescribeValue
@@ -2,8 +2,8 @@ package sobject
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/g8rswimmer/go-sfdc"
@@ -82,8 +82,8 @@ type dml struct {
 	session session.ServiceFormatter
 }
 
-func (d *dml) insertCallout(inserter Inserter) (InsertValue, error) {
-	request, err := d.insertRequest(inserter)
+func (d *dml) insertCallout(ctx context.Context, inserter Inserter) (InsertValue, error) {
+	request, err := d.insertRequest(ctx, inserter)
 
 	if err != nil {
 		return InsertValue{}, err
@@ -97,7 +97,7 @@ func (d *dml) insertCallout(inserter Inserter) (InsertValue, error) {
 
 	return value, nil
 }
-func (d *dml) insertRequest(inserter Inserter) (*http.Request, error) {
+func (d *dml) insertRequest(ctx context.Context, inserter Inserter) (*http.Request, error) {
 
 	url := d.session.ServiceURL() + objectEndpoint + inserter.SObject()
 
@@ -106,7 +106,7 @@ func (d *dml) insertRequest(inserter Inserter) (*http.Request, error) {
 		return nil, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 
 	if err != nil {
 		return nil, err
@@ -130,18 +130,7 @@ func (d *dml) insertResponse(request *http.Request) (InsertValue, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated {
-		var insertErrs []sfdc.Error
-		err = decoder.Decode(&insertErrs)
-		var errMsg error
-		if err == nil {
-			for _, insertErr := range insertErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", insertErr.ErrorCode, insertErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return InsertValue{}, errMsg
+		return InsertValue{}, sfdc.NewAPIError(response)
 	}
 
 	var value InsertValue
@@ -153,8 +142,8 @@ func (d *dml) insertResponse(request *http.Request) (InsertValue, error) {
 	return value, nil
 }
 
-func (d *dml) updateCallout(updater Updater) error {
-	request, err := d.updateRequest(updater)
+func (d *dml) updateCallout(ctx context.Context, updater Updater) error {
+	request, err := d.updateRequest(ctx, updater)
 
 	if err != nil {
 		return err
@@ -164,7 +153,7 @@ func (d *dml) updateCallout(updater Updater) error {
 
 }
 
-func (d *dml) updateRequest(updater Updater) (*http.Request, error) {
+func (d *dml) updateRequest(ctx context.Context, updater Updater) (*http.Request, error) {
 
 	url := d.session.ServiceURL() + objectEndpoint + updater.SObject() + "/" + updater.ID()
 
@@ -172,7 +161,7 @@ func (d *dml) updateRequest(updater Updater) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
-	request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 
 	if err != nil {
 		return nil, err
@@ -191,29 +180,16 @@ func (d *dml) updateResponse(request *http.Request) error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		decoder := json.NewDecoder(response.Body)
-		defer response.Body.Close()
-
-		var updateErrs []sfdc.Error
-		err = decoder.Decode(&updateErrs)
-		var errMsg error
-		if err == nil {
-			for _, updateErr := range updateErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", updateErr.ErrorCode, updateErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return errMsg
+		return sfdc.NewAPIError(response)
 	}
 
 	return nil
 }
-func (d *dml) upsertCallout(upserter Upserter) (UpsertValue, error) {
-	request, err := d.upsertRequest(upserter)
+func (d *dml) upsertCallout(ctx context.Context, upserter Upserter) (UpsertValue, error) {
+	request, err := d.upsertRequest(ctx, upserter)
 
 	if err != nil {
 		return UpsertValue{}, err
@@ -228,7 +204,7 @@ func (d *dml) upsertCallout(upserter Upserter) (UpsertValue, error) {
 	return value, nil
 
 }
-func (d *dml) upsertRequest(upserter Upserter) (*http.Request, error) {
+func (d *dml) upsertRequest(ctx context.Context, upserter Upserter) (*http.Request, error) {
 
 	url := d.session.ServiceURL() + objectEndpoint + upserter.SObject() + "/" + upserter.ExternalField() + "/" + upserter.ID()
 
@@ -236,7 +212,7 @@ func (d *dml) upsertRequest(upserter Upserter) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
-	request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
 
 	if err != nil {
 		return nil, err
@@ -273,24 +249,16 @@ func (d *dml) upsertResponse(request *http.Request) (UpsertValue, error) {
 		isInsert = false
 	default:
 		defer response.Body.Close()
-		var upsetErrs []sfdc.Error
-		err = decoder.Decode(&upsetErrs)
-		errMsg := fmt.Errorf("upsert response err: %d %s", response.StatusCode, response.Status)
-		if err == nil {
-			for _, updateErr := range upsetErrs {
-				errMsg = fmt.Errorf("upsert response err: %s: %s", updateErr.ErrorCode, updateErr.Message)
-			}
-		}
-		return UpsertValue{}, errMsg
+		return UpsertValue{}, sfdc.NewAPIError(response)
 	}
 
 	value.Inserted = isInsert
 
 	return value, nil
 }
-func (d *dml) deleteCallout(deleter Deleter) error {
+func (d *dml) deleteCallout(ctx context.Context, deleter Deleter) error {
 
-	request, err := d.deleteRequest(deleter)
+	request, err := d.deleteRequest(ctx, deleter)
 
 	if err != nil {
 		return err
@@ -298,11 +266,11 @@ func (d *dml) deleteCallout(deleter Deleter) error {
 
 	return d.deleteResponse(request)
 }
-func (d *dml) deleteRequest(deleter Deleter) (*http.Request, error) {
+func (d *dml) deleteRequest(ctx context.Context, deleter Deleter) (*http.Request, error) {
 
 	url := d.session.ServiceURL() + objectEndpoint + deleter.SObject() + "/" + deleter.ID()
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 
 	if err != nil {
 		return nil, err
@@ -319,9 +287,10 @@ func (d *dml) deleteResponse(request *http.Request) error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("delete has failed %d %s", response.StatusCode, response.Status)
+		return sfdc.NewAPIError(response)
 	}
 
 	return nil
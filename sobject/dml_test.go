@@ -1,6 +1,7 @@
 package sobject
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -193,7 +194,7 @@ func Test_dml_Insert(t *testing.T) {
 			d := &dml{
 				session: tt.fields.session,
 			}
-			got, err := d.insertCallout(tt.args.inserter)
+			got, err := d.insertCallout(context.Background(), tt.args.inserter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("dml.Insert() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -319,7 +320,7 @@ func Test_dml_Update(t *testing.T) {
 			d := &dml{
 				session: tt.fields.session,
 			}
-			if err := d.updateCallout(tt.args.updater); (err != nil) != tt.wantErr {
+			if err := d.updateCallout(context.Background(), tt.args.updater); (err != nil) != tt.wantErr {
 				t.Errorf("dml.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -564,7 +565,7 @@ func Test_dml_Upsert(t *testing.T) {
 			d := &dml{
 				session: tt.fields.session,
 			}
-			got, err := d.upsertCallout(tt.args.upserter)
+			got, err := d.upsertCallout(context.Background(), tt.args.upserter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("dml.Upsert() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -667,7 +668,7 @@ func Test_dml_Delete(t *testing.T) {
 			d := &dml{
 				session: tt.fields.session,
 			}
-			if err := d.deleteCallout(tt.args.deleter); (err != nil) != tt.wantErr {
+			if err := d.deleteCallout(context.Background(), tt.args.deleter); (err != nil) != tt.wantErr {
 				t.Errorf("dml.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1,6 +1,7 @@
 package sobject
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -61,6 +62,11 @@ func NewResources(session session.ServiceFormatter) (*Resources, error) {
 
 // Metadata retrieves the SObject's metadata.
 func (r *Resources) Metadata(sobject string) (MetadataValue, error) {
+	return r.MetadataWithContext(context.Background(), sobject)
+}
+
+// MetadataWithContext retrieves the SObject's metadata using the context for the request.
+func (r *Resources) MetadataWithContext(ctx context.Context, sobject string) (MetadataValue, error) {
 	if r.metadata == nil {
 		return MetadataValue{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -74,11 +80,16 @@ func (r *Resources) Metadata(sobject string) (MetadataValue, error) {
 		return MetadataValue{}, fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
 	}
 
-	return r.metadata.callout(sobject)
+	return r.metadata.callout(ctx, sobject)
 }
 
 // Describe retrieves the SObject's describe.
 func (r *Resources) Describe(sobject string) (DescribeValue, error) {
+	return r.DescribeWithContext(context.Background(), sobject)
+}
+
+// DescribeWithContext retrieves the SObject's describe using the context for the request.
+func (r *Resources) DescribeWithContext(ctx context.Context, sobject string) (DescribeValue, error) {
 	if r.describe == nil {
 		return DescribeValue{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -92,11 +103,16 @@ func (r *Resources) Describe(sobject string) (DescribeValue, error) {
 		return DescribeValue{}, fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
 	}
 
-	return r.describe.callout(sobject)
+	return r.describe.callout(ctx, sobject)
 }
 
 // Insert will create a new Salesforce record.
 func (r *Resources) Insert(inserter Inserter) (InsertValue, error) {
+	return r.InsertWithContext(context.Background(), inserter)
+}
+
+// InsertWithContext will create a new Salesforce record using the context for the request.
+func (r *Resources) InsertWithContext(ctx context.Context, inserter Inserter) (InsertValue, error) {
 	if r.dml == nil {
 		return InsertValue{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -105,12 +121,17 @@ func (r *Resources) Insert(inserter Inserter) (InsertValue, error) {
 		return InsertValue{}, errors.New("inserter can not be nil")
 	}
 
-	return r.dml.insertCallout(inserter)
+	return r.dml.insertCallout(ctx, inserter)
 
 }
 
 // Update will update an existing Salesforce record.
 func (r *Resources) Update(updater Updater) error {
+	return r.UpdateWithContext(context.Background(), updater)
+}
+
+// UpdateWithContext will update an existing Salesforce record using the context for the request.
+func (r *Resources) UpdateWithContext(ctx context.Context, updater Updater) error {
 	if r.dml == nil {
 		return errors.New("salesforce api is not initialized properly")
 	}
@@ -119,12 +140,17 @@ func (r *Resources) Update(updater Updater) error {
 		return errors.New("updater can not be nil")
 	}
 
-	return r.dml.updateCallout(updater)
+	return r.dml.updateCallout(ctx, updater)
 
 }
 
 // Upsert will upsert an existing or new Salesforce record.
 func (r *Resources) Upsert(upserter Upserter) (UpsertValue, error) {
+	return r.UpsertWithContext(context.Background(), upserter)
+}
+
+// UpsertWithContext will upsert an existing or new Salesforce record using the context for the request.
+func (r *Resources) UpsertWithContext(ctx context.Context, upserter Upserter) (UpsertValue, error) {
 	if r.dml == nil {
 		return UpsertValue{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -133,12 +159,17 @@ func (r *Resources) Upsert(upserter Upserter) (UpsertValue, error) {
 		return UpsertValue{}, errors.New("upserter can not be nil")
 	}
 
-	return r.dml.upsertCallout(upserter)
+	return r.dml.upsertCallout(ctx, upserter)
 
 }
 
 // Delete will delete an existing Salesforce record.
 func (r *Resources) Delete(deleter Deleter) error {
+	return r.DeleteWithContext(context.Background(), deleter)
+}
+
+// DeleteWithContext will delete an existing Salesforce record using the context for the request.
+func (r *Resources) DeleteWithContext(ctx context.Context, deleter Deleter) error {
 	if r.dml == nil {
 		return errors.New("salesforce api is not initialized properly")
 	}
@@ -147,11 +178,16 @@ func (r *Resources) Delete(deleter Deleter) error {
 		return errors.New("deleter can not be nil")
 	}
 
-	return r.dml.deleteCallout(deleter)
+	return r.dml.deleteCallout(ctx, deleter)
 }
 
 // Query returns a SObject record using the Salesforce ID.
 func (r *Resources) Query(querier Querier) (*sfdc.Record, error) {
+	return r.QueryWithContext(context.Background(), querier)
+}
+
+// QueryWithContext returns a SObject record using the Salesforce ID using the context for the request.
+func (r *Resources) QueryWithContext(ctx context.Context, querier Querier) (*sfdc.Record, error) {
 	if r.query == nil {
 		return nil, errors.New("salesforce api is not initialized properly")
 	}
@@ -160,11 +196,16 @@ func (r *Resources) Query(querier Querier) (*sfdc.Record, error) {
 		return nil, errors.New("querier can not be nil")
 	}
 
-	return r.query.callout(querier)
+	return r.query.callout(ctx, querier)
 }
 
 // ExternalQuery returns a SObject record using an external ID field.
 func (r *Resources) ExternalQuery(querier ExternalQuerier) (*sfdc.Record, error) {
+	return r.ExternalQueryWithContext(context.Background(), querier)
+}
+
+// ExternalQueryWithContext returns a SObject record using an external ID field using the context for the request.
+func (r *Resources) ExternalQueryWithContext(ctx context.Context, querier ExternalQuerier) (*sfdc.Record, error) {
 	if r.query == nil {
 		return nil, errors.New("salesforce api is not initialized properly")
 	}
@@ -173,11 +214,16 @@ func (r *Resources) ExternalQuery(querier ExternalQuerier) (*sfdc.Record, error)
 		return nil, errors.New("querier can not be nil")
 	}
 
-	return r.query.externalCallout(querier)
+	return r.query.externalCallout(ctx, querier)
 }
 
 // DeletedRecords returns a list of records that have been deleted from a date range.
 func (r *Resources) DeletedRecords(sobject string, startDate, endDate time.Time) (DeletedRecords, error) {
+	return r.DeletedRecordsWithContext(context.Background(), sobject, startDate, endDate)
+}
+
+// DeletedRecordsWithContext returns a list of records that have been deleted from a date range using the context for the request.
+func (r *Resources) DeletedRecordsWithContext(ctx context.Context, sobject string, startDate, endDate time.Time) (DeletedRecords, error) {
 	if r.query == nil {
 		return DeletedRecords{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -191,11 +237,16 @@ func (r *Resources) DeletedRecords(sobject string, startDate, endDate time.Time)
 		return DeletedRecords{}, fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
 	}
 
-	return r.query.deletedRecordsCallout(sobject, startDate, endDate)
+	return r.query.deletedRecordsCallout(ctx, sobject, startDate, endDate)
 }
 
 // UpdatedRecords returns a list of records that have been updated from a date range.
 func (r *Resources) UpdatedRecords(sobject string, startDate, endDate time.Time) (UpdatedRecords, error) {
+	return r.UpdatedRecordsWithContext(context.Background(), sobject, startDate, endDate)
+}
+
+// UpdatedRecordsWithContext returns a list of records that have been updated from a date range using the context for the request.
+func (r *Resources) UpdatedRecordsWithContext(ctx context.Context, sobject string, startDate, endDate time.Time) (UpdatedRecords, error) {
 	if r.query == nil {
 		return UpdatedRecords{}, errors.New("salesforce api is not initialized properly")
 	}
@@ -209,11 +260,16 @@ func (r *Resources) UpdatedRecords(sobject string, startDate, endDate time.Time)
 		return UpdatedRecords{}, fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
 	}
 
-	return r.query.updatedRecordsCallout(sobject, startDate, endDate)
+	return r.query.updatedRecordsCallout(ctx, sobject, startDate, endDate)
 }
 
 // GetContent returns the blob from a content SObject.
 func (r *Resources) GetContent(id string, content ContentType) ([]byte, error) {
+	return r.GetContentWithContext(context.Background(), id, content)
+}
+
+// GetContentWithContext returns the blob from a content SObject using the context for the request.
+func (r *Resources) GetContentWithContext(ctx context.Context, id string, content ContentType) ([]byte, error) {
 	if r.query == nil {
 		return nil, errors.New("salesforce api is not initialized properly")
 	}
@@ -229,5 +285,5 @@ func (r *Resources) GetContent(id string, content ContentType) ([]byte, error) {
 		return nil, fmt.Errorf("sobject salesforce: content type (%s) is not supported", string(content))
 	}
 
-	return r.query.contentCallout(id, content)
+	return r.query.contentCallout(ctx, id, content)
 }
@@ -1,8 +1,8 @@
 package sobject
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/g8rswimmer/go-sfdc"
@@ -47,9 +47,9 @@ type metadata struct {
 	session session.ServiceFormatter
 }
 
-func (md *metadata) callout(sobject string) (MetadataValue, error) {
+func (md *metadata) callout(ctx context.Context, sobject string) (MetadataValue, error) {
 
-	request, err := md.request(sobject)
+	request, err := md.request(ctx, sobject)
 
 	if err != nil {
 		return MetadataValue{}, err
@@ -64,10 +64,10 @@ func (md *metadata) callout(sobject string) (MetadataValue, error) {
 	return value, nil
 }
 
-func (md *metadata) request(sobject string) (*http.Request, error) {
+func (md *metadata) request(ctx context.Context, sobject string) (*http.Request, error) {
 	url := md.session.ServiceURL() + objectEndpoint + sobject
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	if err != nil {
 		return nil, err
@@ -90,17 +90,7 @@ func (md *metadata) response(request *http.Request) (MetadataValue, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var respErrs []sfdc.Error
-		err = decoder.Decode(&respErrs)
-		var errMsg error
-		if err == nil {
-			for _, respErr := range respErrs {
-				errMsg = fmt.Errorf("metadata response err: %s: %s", respErr.ErrorCode, respErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("metadata response err: %d %s", response.StatusCode, response.Status)
-		}
-		return MetadataValue{}, errMsg
+		return MetadataValue{}, sfdc.NewAPIError(response)
 	}
 
 	var value MetadataValue
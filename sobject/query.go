@@ -1,8 +1,8 @@
 package sobject
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -84,8 +84,8 @@ type query struct {
 	session session.ServiceFormatter
 }
 
-func (q *query) callout(querier Querier) (*sfdc.Record, error) {
-	request, err := q.queryRequest(querier)
+func (q *query) callout(ctx context.Context, querier Querier) (*sfdc.Record, error) {
+	request, err := q.queryRequest(ctx, querier)
 
 	if err != nil {
 		return nil, err
@@ -99,7 +99,7 @@ func (q *query) callout(querier Querier) (*sfdc.Record, error) {
 
 	return value, nil
 }
-func (q *query) queryRequest(querier Querier) (*http.Request, error) {
+func (q *query) queryRequest(ctx context.Context, querier Querier) (*http.Request, error) {
 
 	queryURL := q.session.ServiceURL() + objectEndpoint + querier.SObject() + "/" + querier.ID()
 
@@ -110,7 +110,7 @@ func (q *query) queryRequest(querier Querier) (*http.Request, error) {
 		queryURL += "?" + form.Encode()
 	}
 
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -133,18 +133,7 @@ func (q *query) queryResponse(request *http.Request) (*sfdc.Record, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var queryErrs []sfdc.Error
-		err = decoder.Decode(&queryErrs)
-		var errMsg error
-		if err == nil {
-			for _, queryErr := range queryErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", queryErr.ErrorCode, queryErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return nil, errMsg
+		return nil, sfdc.NewAPIError(response)
 	}
 
 	var record sfdc.Record
@@ -156,8 +145,8 @@ func (q *query) queryResponse(request *http.Request) (*sfdc.Record, error) {
 	return &record, nil
 }
 
-func (q *query) externalCallout(querier ExternalQuerier) (*sfdc.Record, error) {
-	request, err := q.externalQueryRequest(querier)
+func (q *query) externalCallout(ctx context.Context, querier ExternalQuerier) (*sfdc.Record, error) {
+	request, err := q.externalQueryRequest(ctx, querier)
 
 	if err != nil {
 		return nil, err
@@ -172,7 +161,7 @@ func (q *query) externalCallout(querier ExternalQuerier) (*sfdc.Record, error) {
 	return value, nil
 }
 
-func (q *query) externalQueryRequest(querier ExternalQuerier) (*http.Request, error) {
+func (q *query) externalQueryRequest(ctx context.Context, querier ExternalQuerier) (*http.Request, error) {
 
 	queryURL := q.session.ServiceURL() + objectEndpoint + querier.SObject() + "/" + querier.ExternalField() + "/" + querier.ID()
 
@@ -183,7 +172,7 @@ func (q *query) externalQueryRequest(querier ExternalQuerier) (*http.Request, er
 		queryURL += "?" + form.Encode()
 	}
 
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -194,8 +183,8 @@ func (q *query) externalQueryRequest(querier ExternalQuerier) (*http.Request, er
 	return request, nil
 
 }
-func (q *query) deletedRecordsCallout(sobject string, startDate, endDate time.Time) (DeletedRecords, error) {
-	request, err := q.operationRequest(sobject, deletedRoute, startDate, endDate)
+func (q *query) deletedRecordsCallout(ctx context.Context, sobject string, startDate, endDate time.Time) (DeletedRecords, error) {
+	request, err := q.operationRequest(ctx, sobject, deletedRoute, startDate, endDate)
 
 	if err != nil {
 		return DeletedRecords{}, err
@@ -221,7 +210,7 @@ func (q *query) deletedRecordsResponse(request *http.Request) (DeletedRecords, e
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return DeletedRecords{}, fmt.Errorf("deleted records response err: %d %s", response.StatusCode, response.Status)
+		return DeletedRecords{}, sfdc.NewAPIError(response)
 	}
 
 	var records DeletedRecords
@@ -252,8 +241,8 @@ func (q *query) deletedRecordsResponse(request *http.Request) (DeletedRecords, e
 	return records, nil
 }
 
-func (q *query) updatedRecordsCallout(sobject string, startDate, endDate time.Time) (UpdatedRecords, error) {
-	request, err := q.operationRequest(sobject, updatedRoute, startDate, endDate)
+func (q *query) updatedRecordsCallout(ctx context.Context, sobject string, startDate, endDate time.Time) (UpdatedRecords, error) {
+	request, err := q.operationRequest(ctx, sobject, updatedRoute, startDate, endDate)
 
 	if err != nil {
 		return UpdatedRecords{}, err
@@ -279,7 +268,7 @@ func (q *query) updatedRecordsResponse(request *http.Request) (UpdatedRecords, e
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return UpdatedRecords{}, fmt.Errorf("deleted records response err: %d %s", response.StatusCode, response.Status)
+		return UpdatedRecords{}, sfdc.NewAPIError(response)
 	}
 
 	var records UpdatedRecords
@@ -297,7 +286,7 @@ func (q *query) updatedRecordsResponse(request *http.Request) (UpdatedRecords, e
 	return records, nil
 }
 
-func (q *query) operationRequest(sobject, operation string, startDate, endDate time.Time) (*http.Request, error) {
+func (q *query) operationRequest(ctx context.Context, sobject, operation string, startDate, endDate time.Time) (*http.Request, error) {
 
 	form := url.Values{}
 	form.Add("start", startDate.Format(time.RFC3339))
@@ -306,7 +295,7 @@ func (q *query) operationRequest(sobject, operation string, startDate, endDate t
 
 	queryURL := q.session.ServiceURL() + objectEndpoint + sobject + "/" + operation + "/" + dateRange
 
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -318,8 +307,8 @@ func (q *query) operationRequest(sobject, operation string, startDate, endDate t
 
 }
 
-func (q *query) contentCallout(id string, content ContentType) ([]byte, error) {
-	request, err := q.contentRequest(id, content)
+func (q *query) contentCallout(ctx context.Context, id string, content ContentType) ([]byte, error) {
+	request, err := q.contentRequest(ctx, id, content)
 
 	if err != nil {
 		return nil, err
@@ -327,11 +316,11 @@ func (q *query) contentCallout(id string, content ContentType) ([]byte, error) {
 
 	return q.contentResponse(request)
 }
-func (q *query) contentRequest(id string, content ContentType) (*http.Request, error) {
+func (q *query) contentRequest(ctx context.Context, id string, content ContentType) (*http.Request, error) {
 
 	queryURL := q.session.ServiceURL() + objectEndpoint + string(content) + "/" + id + "/" + contentBody
 
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -348,12 +337,13 @@ func (q *query) contentResponse(request *http.Request) ([]byte, error) {
 		return nil, err
 	}
 
+	defer response.Body.Close()
+
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("deleted records response err: %d %s", response.StatusCode, response.Status)
+		return nil, sfdc.NewAPIError(response)
 	}
 
 	body, err := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
 
 	return body, err
 }
@@ -2,6 +2,7 @@ package tree
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,6 +53,11 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 
 // Insert will call the composite tree API.
 func (r *Resource) Insert(inserter Inserter) (*Value, error) {
+	return r.InsertWithContext(context.Background(), inserter)
+}
+
+// InsertWithContext will call the composite tree API using the context for the request.
+func (r *Resource) InsertWithContext(ctx context.Context, inserter Inserter) (*Value, error) {
 	if inserter == nil {
 		return nil, errors.New("tree resourse: inserter can not be nil")
 	}
@@ -64,11 +70,11 @@ func (r *Resource) Insert(inserter Inserter) (*Value, error) {
 		return nil, fmt.Errorf("tree resourse: %s is not a valid sobject", sobject)
 	}
 
-	return r.callout(inserter)
+	return r.callout(ctx, inserter)
 }
-func (r *Resource) callout(inserter Inserter) (*Value, error) {
+func (r *Resource) callout(ctx context.Context, inserter Inserter) (*Value, error) {
 
-	request, err := r.request(inserter)
+	request, err := r.request(ctx, inserter)
 
 	if err != nil {
 		return nil, err
@@ -82,7 +88,7 @@ func (r *Resource) callout(inserter Inserter) (*Value, error) {
 
 	return &value, nil
 }
-func (r *Resource) request(inserter Inserter) (*http.Request, error) {
+func (r *Resource) request(ctx context.Context, inserter Inserter) (*http.Request, error) {
 
 	url := r.session.ServiceURL() + objectEndpoint + inserter.SObject()
 
@@ -91,7 +97,7 @@ func (r *Resource) request(inserter Inserter) (*http.Request, error) {
 		return nil, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, url, body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 
 	if err != nil {
 		return nil, err
@@ -132,7 +138,11 @@ func (r *Resource) response(request *http.Request) (Value, error) {
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return value, fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
+		apiErr := sfdc.NewAPIError(response)
+		for _, result := range value.Results {
+			apiErr.Errors = append(apiErr.Errors, result.Errors...)
+		}
+		return value, apiErr
 	}
 
 	return value, nil
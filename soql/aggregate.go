@@ -0,0 +1,211 @@
+package soql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/g8rswimmer/go-sfdc"
+)
+
+// AggregateObject is the SObject of the aggregate query records.
+const AggregateObject = "AggregateResult"
+
+// Count forms the COUNT aggregate function of the field.  An empty field forms
+// COUNT(), which only returns the query result's total size.
+func Count(field string) string {
+	return aggregate("COUNT", field)
+}
+
+// CountDistinct forms the COUNT_DISTINCT aggregate function of the field.
+func CountDistinct(field string) string {
+	return aggregate("COUNT_DISTINCT", field)
+}
+
+// Sum forms the SUM aggregate function of the field.
+func Sum(field string) string {
+	return aggregate("SUM", field)
+}
+
+// Avg forms the AVG aggregate function of the field.
+func Avg(field string) string {
+	return aggregate("AVG", field)
+}
+
+// Min forms the MIN aggregate function of the field.
+func Min(field string) string {
+	return aggregate("MIN", field)
+}
+
+// Max forms the MAX aggregate function of the field.
+func Max(field string) string {
+	return aggregate("MAX", field)
+}
+
+// Grouping forms the GROUPING function of the field, which indicates if the
+// row is a ROLLUP or CUBE subtotal of the field.
+func Grouping(field string) string {
+	return aggregate("GROUPING", field)
+}
+
+// Alias names the aggregate function in the result.  Without an alias, the
+// aggregate functions are named expr0, expr1 and so on.
+func Alias(expression, alias string) string {
+	return expression + " " + alias
+}
+
+func aggregate(function, field string) string {
+	return fmt.Sprintf("%s(%s)", function, field)
+}
+
+// GroupByType is the type of grouping of the query result.
+type GroupByType string
+
+const (
+	// GroupByFields groups the results by the fields.
+	GroupByFields GroupByType = ""
+	// GroupByRollup groups the results by the fields, with subtotals for each
+	// level of the fields and a grand total.
+	GroupByRollup GroupByType = "ROLLUP"
+	// GroupByCube groups the results by the fields, with subtotals for every
+	// combination of the fields and a grand total.
+	GroupByCube GroupByType = "CUBE"
+)
+
+// GroupBy is the grouping structure of the SOQL query.
+type GroupBy struct {
+	fields   []string
+	grouping GroupByType
+}
+
+// Grouper is the interface for returning the SOQL grouping.
+type Grouper interface {
+	GroupBy() (string, error)
+}
+
+// NewGroupBy creates a GroupBy structure.  If the grouping type is not valid or
+// there are no fields, an error will be returned.
+func NewGroupBy(grouping GroupByType, fields ...string) (*GroupBy, error) {
+	switch grouping {
+	case GroupByFields, GroupByRollup, GroupByCube:
+	default:
+		return nil, fmt.Errorf("group by: %s is not a valid grouping type", string(grouping))
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("group by: fields can not be empty")
+	}
+	return &GroupBy{
+		fields:   fields,
+		grouping: grouping,
+	}, nil
+}
+
+// GroupBy returns the group by SOQL string.
+func (g *GroupBy) GroupBy() (string, error) {
+	if len(g.fields) == 0 {
+		return "", errors.New("group by: fields can not be empty")
+	}
+	fields := strings.Join(g.fields, ",")
+	switch g.grouping {
+	case GroupByFields:
+		return "GROUP BY " + fields, nil
+	case GroupByRollup, GroupByCube:
+		return fmt.Sprintf("GROUP BY %s(%s)", string(g.grouping), fields), nil
+	}
+	return "", fmt.Errorf("group by: %s is not a valid grouping type", string(g.grouping))
+}
+
+// AggregateResult is a record of an aggregate query.  The values are named by
+// the alias, the grouped field's name or the exprN name of the aggregate
+// function without an alias.
+type AggregateResult struct {
+	record *sfdc.Record
+}
+
+// AggregateResult returns the aggregate result of the query record.  If the
+// record is not from an aggregate query, an error is returned.
+func (rec *QueryRecord) AggregateResult() (*AggregateResult, error) {
+	if rec.record == nil || rec.record.SObject() != AggregateObject {
+		return nil, errors.New("aggregate result: record is not an aggregate result")
+	}
+	return &AggregateResult{
+		record: rec.record,
+	}, nil
+}
+
+// AggregateResults returns the aggregate results of the query records.  If a
+// record is not from an aggregate query, an error is returned.
+func (result *QueryResult) AggregateResults() ([]*AggregateResult, error) {
+	results := make([]*AggregateResult, len(result.records))
+	for idx, record := range result.records {
+		aggregate, err := record.AggregateResult()
+		if err != nil {
+			return nil, err
+		}
+		results[idx] = aggregate
+	}
+	return results, nil
+}
+
+// Value returns the value of the name.  If there is not a value, false is
+// returned.
+func (ar *AggregateResult) Value(name string) (interface{}, bool) {
+	if ar.record.IsNull(name) {
+		return nil, true
+	}
+	return ar.record.FieldValue(name)
+}
+
+// Expr returns the value of the aggregate function, without an alias, at the
+// index.  If there is not a value, false is returned.
+func (ar *AggregateResult) Expr(index int) (interface{}, bool) {
+	return ar.Value(fmt.Sprintf("expr%d", index))
+}
+
+// Values returns the map of the names to values.
+func (ar *AggregateResult) Values() map[string]interface{} {
+	return ar.record.Fields()
+}
+
+// StringValue returns the string value of the name.
+func (ar *AggregateResult) StringValue(name string) (string, error) {
+	return ar.record.StringValue(name)
+}
+
+// Int64Value returns the integer value of the name, like a COUNT.
+func (ar *AggregateResult) Int64Value(name string) (int64, error) {
+	return ar.record.Int64Value(name)
+}
+
+// Float64Value returns the floating point value of the name, like an AVG.
+func (ar *AggregateResult) Float64Value(name string) (float64, error) {
+	return ar.record.Float64Value(name)
+}
+
+// NumberValue returns the number of the name as it was in the JSON response,
+// like a SUM of a currency field.
+func (ar *AggregateResult) NumberValue(name string) (json.Number, error) {
+	return ar.record.NumberValue(name)
+}
+
+// IsNull returns true if the value of the name is null, like the grouped field
+// of a ROLLUP or CUBE subtotal.
+func (ar *AggregateResult) IsNull(name string) bool {
+	return ar.record.IsNull(name)
+}
+
+// Grouping returns the GROUPING function value of the name, which is true if
+// the row is a subtotal of the grouped field.
+func (ar *AggregateResult) Grouping(name string) (bool, error) {
+	value, err := ar.record.Int64Value(name)
+	if err != nil {
+		return false, err
+	}
+	return value == 1, nil
+}
+
+// Record returns the aggregate query record.
+func (ar *AggregateResult) Record() *sfdc.Record {
+	return ar.record
+}
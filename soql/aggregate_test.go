@@ -0,0 +1,280 @@
+package soql
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAggregateFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "Count",
+			got:  Count(""),
+			want: "COUNT()",
+		},
+		{
+			name: "Count Field",
+			got:  Count("Id"),
+			want: "COUNT(Id)",
+		},
+		{
+			name: "Count Distinct",
+			got:  CountDistinct("Type"),
+			want: "COUNT_DISTINCT(Type)",
+		},
+		{
+			name: "Sum Alias",
+			got:  Alias(Sum("Amount"), "total"),
+			want: "SUM(Amount) total",
+		},
+		{
+			name: "Avg",
+			got:  Avg("Amount"),
+			want: "AVG(Amount)",
+		},
+		{
+			name: "Min",
+			got:  Min("CloseDate"),
+			want: "MIN(CloseDate)",
+		},
+		{
+			name: "Max",
+			got:  Max("CloseDate"),
+			want: "MAX(CloseDate)",
+		},
+		{
+			name: "Grouping",
+			got:  Alias(Grouping("StageName"), "grpStage"),
+			want: "GROUPING(StageName) grpStage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGroupBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		grouping GroupByType
+		fields   []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Fields",
+			grouping: GroupByFields,
+			fields:   []string{"StageName", "Type"},
+			want:     "GROUP BY StageName,Type",
+		},
+		{
+			name:     "Rollup",
+			grouping: GroupByRollup,
+			fields:   []string{"StageName", "Type"},
+			want:     "GROUP BY ROLLUP(StageName,Type)",
+		},
+		{
+			name:     "Cube",
+			grouping: GroupByCube,
+			fields:   []string{"StageName"},
+			want:     "GROUP BY CUBE(StageName)",
+		},
+		{
+			name:     "Invalid",
+			grouping: GroupByType("SIDEWAYS"),
+			fields:   []string{"StageName"},
+			wantErr:  true,
+		},
+		{
+			name:     "No Fields",
+			grouping: GroupByFields,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupBy, err := NewGroupBy(tt.grouping, tt.fields...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGroupBy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := groupBy.GroupBy()
+			if err != nil {
+				t.Errorf("GroupBy.GroupBy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GroupBy.GroupBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_FormatAggregate(t *testing.T) {
+	groupBy, err := NewGroupBy(GroupByRollup, "StageName")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	having, err := WhereGreaterThan(Sum("Amount"), 1000, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	order, err := NewOrderBy(OrderDesc)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	order.FieldOrder(Sum("Amount"))
+	query, err := NewQuery(QueryInput{
+		ObjectType: "Opportunity",
+		FieldList: []string{
+			"StageName",
+			Alias(Sum("Amount"), "total"),
+			Count("Id"),
+			Alias(Grouping("StageName"), "grpStage"),
+		},
+		GroupBy: groupBy,
+		Having:  having,
+		Order:   order,
+		Limit:   10,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := query.Format()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	want := "SELECT StageName,SUM(Amount) total,COUNT(Id),GROUPING(StageName) grpStage FROM Opportunity GROUP BY ROLLUP(StageName) HAVING SUM(Amount) > 1000 ORDER BY SUM(Amount) DESC LIMIT 10"
+	if got != want {
+		t.Errorf("Query.Format() = %v, want %v", got, want)
+	}
+
+	query.groupBy = &GroupBy{}
+	if _, err := query.Format(); err == nil {
+		t.Error("Query.Format() expected an error for an empty group by")
+	}
+}
+
+func testAggregateResult(t *testing.T, body string) *QueryResult {
+	var jsonMap map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonMap); err != nil {
+		t.Fatal(err.Error())
+	}
+	response, err := newQueryResponseJSON(jsonMap)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	result, err := newQueryResult(response, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return result
+}
+
+func TestQueryResult_AggregateResults(t *testing.T) {
+	result := testAggregateResult(t, `
+	{
+		"totalSize": 2,
+		"done": true,
+		"records": [
+			{
+				"attributes": {"type": "AggregateResult"},
+				"StageName": "Closed Won",
+				"total": 12345678901234567.89,
+				"expr0": 4,
+				"grpStage": 0
+			},
+			{
+				"attributes": {"type": "AggregateResult"},
+				"StageName": null,
+				"total": 12345678901234569.89,
+				"expr0": 5,
+				"grpStage": 1
+			}
+		]
+	}`)
+	aggregates, err := result.AggregateResults()
+	if err != nil {
+		t.Fatalf("QueryResult.AggregateResults() error = %v", err)
+	}
+	if len(aggregates) != 2 {
+		t.Fatalf("QueryResult.AggregateResults() = %d results, want 2", len(aggregates))
+	}
+
+	detail := aggregates[0]
+	if stage, err := detail.StringValue("StageName"); err != nil || stage != "Closed Won" {
+		t.Errorf("AggregateResult.StringValue() = %v, %v", stage, err)
+	}
+	if total, err := detail.NumberValue("total"); err != nil || total != "12345678901234567.89" {
+		t.Errorf("AggregateResult.NumberValue() = %v, %v", total, err)
+	}
+	if count, err := detail.Int64Value("expr0"); err != nil || count != 4 {
+		t.Errorf("AggregateResult.Int64Value() = %v, %v", count, err)
+	}
+	if count, has := detail.Expr(0); has == false || count != float64(4) {
+		t.Errorf("AggregateResult.Expr() = %v, %v", count, has)
+	}
+	if grouping, err := detail.Grouping("grpStage"); err != nil || grouping {
+		t.Errorf("AggregateResult.Grouping() = %v, %v", grouping, err)
+	}
+	if _, has := detail.Expr(1); has {
+		t.Error("AggregateResult.Expr() expected expr1 to be absent")
+	}
+
+	subtotal := aggregates[1]
+	if subtotal.IsNull("StageName") == false {
+		t.Error("AggregateResult.IsNull() expected the subtotal stage to be null")
+	}
+	if value, has := subtotal.Value("StageName"); has == false || value != nil {
+		t.Errorf("AggregateResult.Value() = %v, %v", value, has)
+	}
+	if grouping, err := subtotal.Grouping("grpStage"); err != nil || grouping == false {
+		t.Errorf("AggregateResult.Grouping() = %v, %v", grouping, err)
+	}
+	if avg, err := subtotal.Float64Value("expr0"); err != nil || avg != 5 {
+		t.Errorf("AggregateResult.Float64Value() = %v, %v", avg, err)
+	}
+	want := map[string]interface{}{
+		"total":    12345678901234569.89,
+		"expr0":    float64(5),
+		"grpStage": float64(1),
+	}
+	if !reflect.DeepEqual(subtotal.Values(), want) {
+		t.Errorf("AggregateResult.Values() = %v, want %v", subtotal.Values(), want)
+	}
+	if subtotal.Record().SObject() != AggregateObject {
+		t.Errorf("AggregateResult.Record() = %v, want %v", subtotal.Record().SObject(), AggregateObject)
+	}
+}
+
+func TestQueryResult_AggregateResultsError(t *testing.T) {
+	result := testAggregateResult(t, `
+	{
+		"totalSize": 1,
+		"done": true,
+		"records": [
+			{
+				"attributes": {"type": "Account"},
+				"Name": "Acme"
+			}
+		]
+	}`)
+	if _, err := result.AggregateResults(); err == nil {
+		t.Error("QueryResult.AggregateResults() expected an error for an sobject record")
+	}
+}
@@ -0,0 +1,233 @@
+package soql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TypeOf is the polymorphic field selection, where the fields that are
+// selected depend on the SObject of the relationship, like
+// TYPEOF What WHEN Account THEN Phone ELSE Name END.
+type TypeOf struct {
+	field      string
+	whens      []string
+	elseFields []string
+}
+
+// NewTypeOf creates a TypeOf structure for the polymorphic relationship field.
+// If the field is empty, an error is returned.
+func NewTypeOf(field string) (*TypeOf, error) {
+	if field == "" {
+		return nil, errors.New("typeof: field can not be empty")
+	}
+	return &TypeOf{
+		field: field,
+	}, nil
+}
+
+// When will select the fields when the relationship is the SObject.  If the
+// SObject or the fields are empty, an error is returned.
+func (t *TypeOf) When(sobject string, fields ...string) error {
+	if sobject == "" {
+		return errors.New("typeof: when sobject can not be empty")
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("typeof: when %s fields can not be empty", sobject)
+	}
+	t.whens = append(t.whens, fmt.Sprintf("WHEN %s THEN %s", sobject, strings.Join(fields, ",")))
+	return nil
+}
+
+// Else will select the fields when the relationship is not one of the when
+// SObjects.  If the fields are empty, an error is returned.
+func (t *TypeOf) Else(fields ...string) error {
+	if len(fields) == 0 {
+		return errors.New("typeof: else fields can not be empty")
+	}
+	t.elseFields = fields
+	return nil
+}
+
+// Format returns the TYPEOF SOQL string.  If there is not a when, an error is
+// returned.
+func (t *TypeOf) Format() (string, error) {
+	if t.field == "" {
+		return "", errors.New("typeof: field can not be empty")
+	}
+	if len(t.whens) == 0 {
+		return "", fmt.Errorf("typeof: %s must have a when", t.field)
+	}
+	typeOf := fmt.Sprintf("TYPEOF %s %s", t.field, strings.Join(t.whens, " "))
+	if len(t.elseFields) > 0 {
+		typeOf += " ELSE " + strings.Join(t.elseFields, ",")
+	}
+	return typeOf + " END", nil
+}
+
+// FieldsType is the group of fields selected with FIELDS.
+type FieldsType string
+
+const (
+	// FieldsStandard selects the standard fields.
+	FieldsStandard FieldsType = "STANDARD"
+	// FieldsCustom selects the custom fields.  The query must have a limit
+	// of at most 200 records.
+	FieldsCustom FieldsType = "CUSTOM"
+	// FieldsAll selects all of the fields.  The query must have a limit of at
+	// most 200 records.
+	FieldsAll FieldsType = "ALL"
+)
+
+const fieldsLimit = 200
+
+// Fields forms the FIELDS function, which selects a group of fields, like
+// FIELDS(STANDARD).  It is used in the field list.
+func Fields(fields FieldsType) string {
+	return aggregate("FIELDS", string(fields))
+}
+
+// Wither is the interface for returning the SOQL with filter.
+type Wither interface {
+	With() (string, error)
+}
+
+// WithType is the with filter of the query's security or sharing mode.
+type WithType string
+
+const (
+	// WithSecurityEnforced enforces the field and object level security of the
+	// query.
+	WithSecurityEnforced WithType = "SECURITY_ENFORCED"
+	// WithUserMode runs the query with the user's permissions and sharing.
+	WithUserMode WithType = "USER_MODE"
+	// WithSystemMode runs the query in system mode.
+	WithSystemMode WithType = "SYSTEM_MODE"
+)
+
+// With returns the with SOQL string.  If the type is not valid, an error is
+// returned.
+func (w WithType) With() (string, error) {
+	switch w {
+	case WithSecurityEnforced, WithUserMode, WithSystemMode:
+	default:
+		return "", fmt.Errorf("with: %s is not a valid with type", string(w))
+	}
+	return "WITH " + string(w), nil
+}
+
+// DataCategorySelector is how the data category filter selects the categories.
+type DataCategorySelector string
+
+const (
+	// DataCategoryAt selects the category.
+	DataCategoryAt DataCategorySelector = "AT"
+	// DataCategoryAbove selects the category and its parents.
+	DataCategoryAbove DataCategorySelector = "ABOVE"
+	// DataCategoryBelow selects the category and its children.
+	DataCategoryBelow DataCategorySelector = "BELOW"
+	// DataCategoryAboveOrBelow selects the category, its parents and its children.
+	DataCategoryAboveOrBelow DataCategorySelector = "ABOVE_OR_BELOW"
+)
+
+// DataCategory is the with data category filter of a query on knowledge
+// articles or questions.
+type DataCategory struct {
+	filters []string
+}
+
+// NewDataCategory creates a DataCategory structure with the filter of the
+// category group.  If the filter is not valid, an error is returned.
+func NewDataCategory(group string, selector DataCategorySelector, categories ...string) (*DataCategory, error) {
+	d := &DataCategory{}
+	if err := d.And(group, selector, categories...); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// And will add the filter of another category group.  If the filter is not
+// valid, an error is returned.
+func (d *DataCategory) And(group string, selector DataCategorySelector, categories ...string) error {
+	if group == "" {
+		return errors.New("data category: group can not be empty")
+	}
+	switch selector {
+	case DataCategoryAt, DataCategoryAbove, DataCategoryBelow, DataCategoryAboveOrBelow:
+	default:
+		return fmt.Errorf("data category: %s is not a valid selector", string(selector))
+	}
+	switch len(categories) {
+	case 0:
+		return fmt.Errorf("data category: %s categories can not be empty", group)
+	case 1:
+		d.filters = append(d.filters, fmt.Sprintf("%s %s %s", group, string(selector), categories[0]))
+	default:
+		d.filters = append(d.filters, fmt.Sprintf("%s %s (%s)", group, string(selector), strings.Join(categories, ",")))
+	}
+	return nil
+}
+
+// With returns the with data category SOQL string.  If there are no filters,
+// an error is returned.
+func (d *DataCategory) With() (string, error) {
+	if len(d.filters) == 0 {
+		return "", errors.New("data category: filters can not be empty")
+	}
+	return "WITH DATA CATEGORY " + strings.Join(d.filters, " AND "), nil
+}
+
+// FilterScope is the using scope of the query's records.
+type FilterScope string
+
+const (
+	// ScopeDelegated is the records delegated to another user.
+	ScopeDelegated FilterScope = "delegated"
+	// ScopeEverything is all of the records.
+	ScopeEverything FilterScope = "everything"
+	// ScopeMine is the records owned by the user.
+	ScopeMine FilterScope = "mine"
+	// ScopeMineAndMyGroups is the records owned by the user and the user's queues.
+	ScopeMineAndMyGroups FilterScope = "mine_and_my_groups"
+	// ScopeMyTerritory is the records in the user's territory.
+	ScopeMyTerritory FilterScope = "my_territory"
+	// ScopeMyTeamTerritory is the records in the territory of the user's team.
+	ScopeMyTeamTerritory FilterScope = "my_team_territory"
+	// ScopeTeam is the records assigned to a team.
+	ScopeTeam FilterScope = "team"
+)
+
+// Scope returns the using scope SOQL string.  If the scope is not valid, an
+// error is returned.
+func (f FilterScope) Scope() (string, error) {
+	switch f {
+	case ScopeDelegated, ScopeEverything, ScopeMine, ScopeMineAndMyGroups, ScopeMyTerritory, ScopeMyTeamTerritory, ScopeTeam:
+	default:
+		return "", fmt.Errorf("using scope: %s is not a valid scope", string(f))
+	}
+	return "USING SCOPE " + string(f), nil
+}
+
+// ForType is the record tracking or locking of the query.
+type ForType string
+
+const (
+	// ForView updates the records' last viewed date.
+	ForView ForType = "VIEW"
+	// ForReference updates the records' last referenced date.
+	ForReference ForType = "REFERENCE"
+	// ForUpdate locks the records, so that they can only be updated by the
+	// transaction.  It can not be used with an ordering.
+	ForUpdate ForType = "UPDATE"
+)
+
+// For returns the for SOQL string.  If the type is not valid, an error is
+// returned.
+func (f ForType) For() (string, error) {
+	switch f {
+	case ForView, ForReference, ForUpdate:
+	default:
+		return "", fmt.Errorf("for: %s is not a valid for type", string(f))
+	}
+	return "FOR " + string(f), nil
+}
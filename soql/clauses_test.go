@@ -0,0 +1,290 @@
+package soql
+
+import (
+	"testing"
+)
+
+func TestTypeOf_Format(t *testing.T) {
+	typeOf, err := NewTypeOf("What")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := typeOf.Format(); err == nil {
+		t.Error("TypeOf.Format() expected an error without a when")
+	}
+	if err := typeOf.When("Account", "Phone", "NumberOfEmployees"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := typeOf.When("Opportunity", "Amount"); err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := typeOf.Format()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := "TYPEOF What WHEN Account THEN Phone,NumberOfEmployees WHEN Opportunity THEN Amount END"; got != want {
+		t.Errorf("TypeOf.Format() = %v, want %v", got, want)
+	}
+	if err := typeOf.Else("Name", "Email"); err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err = typeOf.Format()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := "TYPEOF What WHEN Account THEN Phone,NumberOfEmployees WHEN Opportunity THEN Amount ELSE Name,Email END"; got != want {
+		t.Errorf("TypeOf.Format() = %v, want %v", got, want)
+	}
+
+	if _, err := NewTypeOf(""); err == nil {
+		t.Error("NewTypeOf() expected an error for an empty field")
+	}
+	if err := typeOf.When("", "Name"); err == nil {
+		t.Error("TypeOf.When() expected an error for an empty sobject")
+	}
+	if err := typeOf.When("Lead"); err == nil {
+		t.Error("TypeOf.When() expected an error without fields")
+	}
+	if err := typeOf.Else(); err == nil {
+		t.Error("TypeOf.Else() expected an error without fields")
+	}
+}
+
+func TestWithType_With(t *testing.T) {
+	tests := []struct {
+		name    string
+		with    Wither
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Security Enforced",
+			with: WithSecurityEnforced,
+			want: "WITH SECURITY_ENFORCED",
+		},
+		{
+			name: "User Mode",
+			with: WithUserMode,
+			want: "WITH USER_MODE",
+		},
+		{
+			name: "System Mode",
+			with: WithSystemMode,
+			want: "WITH SYSTEM_MODE",
+		},
+		{
+			name:    "Invalid",
+			with:    WithType("GOD_MODE"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty Data Category",
+			with:    &DataCategory{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.with.With()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("With() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("With() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataCategory_With(t *testing.T) {
+	category, err := NewDataCategory("Geography__c", DataCategoryAboveOrBelow, "Europe__c")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := category.And("Product__c", DataCategoryAt, "mobile_phones__c", "laptops__c"); err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := category.With()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := "WITH DATA CATEGORY Geography__c ABOVE_OR_BELOW Europe__c AND Product__c AT (mobile_phones__c,laptops__c)"; got != want {
+		t.Errorf("DataCategory.With() = %v, want %v", got, want)
+	}
+	if _, err := NewDataCategory("", DataCategoryAt, "Europe__c"); err == nil {
+		t.Error("NewDataCategory() expected an error for an empty group")
+	}
+	if _, err := NewDataCategory("Geography__c", DataCategorySelector("NEAR"), "Europe__c"); err == nil {
+		t.Error("NewDataCategory() expected an error for an invalid selector")
+	}
+	if _, err := NewDataCategory("Geography__c", DataCategoryBelow); err == nil {
+		t.Error("NewDataCategory() expected an error without categories")
+	}
+}
+
+func TestFilterScope_Scope(t *testing.T) {
+	got, err := ScopeMineAndMyGroups.Scope()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := "USING SCOPE mine_and_my_groups"; got != want {
+		t.Errorf("FilterScope.Scope() = %v, want %v", got, want)
+	}
+	if _, err := FilterScope("yours").Scope(); err == nil {
+		t.Error("FilterScope.Scope() expected an error for an invalid scope")
+	}
+}
+
+func TestForType_For(t *testing.T) {
+	for _, forType := range []ForType{ForView, ForReference, ForUpdate} {
+		got, err := forType.For()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if want := "FOR " + string(forType); got != want {
+			t.Errorf("ForType.For() = %v, want %v", got, want)
+		}
+	}
+	if _, err := ForType("DELETE").For(); err == nil {
+		t.Error("ForType.For() expected an error for an invalid type")
+	}
+}
+
+func TestQuery_FormatClauses(t *testing.T) {
+	typeOf, err := NewTypeOf("What")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := typeOf.When("Account", "Phone"); err != nil {
+		t.Fatal(err.Error())
+	}
+	where, err := WhereEquals("Subject", "Call")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	order := NewOrderByFields()
+	if err := order.Add("Subject", OrderAsc, OrderNullsLast); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := order.Add("CreatedDate", OrderDesc, ""); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name    string
+		input   QueryInput
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "All Clauses",
+			input: QueryInput{
+				ObjectType: "Event",
+				FieldList:  []string{"Id"},
+				TypeOf:     []*TypeOf{typeOf},
+				Scope:      ScopeMine,
+				Where:      where,
+				With:       WithSecurityEnforced,
+				Order:      order,
+				Limit:      10,
+				For:        ForView,
+			},
+			want: "SELECT Id,TYPEOF What WHEN Account THEN Phone END FROM Event USING SCOPE mine WHERE Subject = 'Call' WITH SECURITY_ENFORCED ORDER BY Subject ASC NULLS LAST,CreatedDate DESC LIMIT 10 FOR VIEW",
+		},
+		{
+			name: "TypeOf Only",
+			input: QueryInput{
+				ObjectType: "Event",
+				TypeOf:     []*TypeOf{typeOf},
+			},
+			want: "SELECT TYPEOF What WHEN Account THEN Phone END FROM Event",
+		},
+		{
+			name: "Fields All",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{Fields(FieldsAll)},
+				Limit:      200,
+			},
+			want: "SELECT FIELDS(ALL) FROM Account LIMIT 200",
+		},
+		{
+			name: "Fields Standard",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{Fields(FieldsStandard)},
+			},
+			want: "SELECT FIELDS(STANDARD) FROM Account",
+		},
+		{
+			name: "Fields Custom Without Limit",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{Fields(FieldsCustom)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "For Update Ordering",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{"Id"},
+				Order:      order,
+				For:        ForUpdate,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid Scope",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{"Id"},
+				Scope:      FilterScope("yours"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid With",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{"Id"},
+				With:       WithType("GOD_MODE"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid For",
+			input: QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{"Id"},
+				For:        ForType("DELETE"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid TypeOf",
+			input: QueryInput{
+				ObjectType: "Event",
+				TypeOf:     []*TypeOf{{field: "What"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewQuery(tt.input)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			got, err := query.Format()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Query.Format() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Query.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package soql
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a date value, which is formatted without the time of day, like 2019-03-26.
+type Date time.Time
+
+// DateTime is a date time value, which is formatted in UTC, like 2019-03-26T15:30:45Z.
+type DateTime time.Time
+
+// DateTimeFormat is the layout of the date time literals.
+const DateTimeFormat = "2006-01-02T15:04:05Z07:00"
+
+// DateFormat is the layout of the date literals.
+const DateFormat = "2006-01-02"
+
+// DateLiteral is a relative date literal, like TODAY or LAST_N_DAYS:30.  The
+// literal is placed in the query as it is, not quoted.
+type DateLiteral string
+
+const (
+	// Yesterday starts at 12:00:00 AM the day before and continues for 24 hours.
+	Yesterday DateLiteral = "YESTERDAY"
+	// Today starts at 12:00:00 AM the current day and continues for 24 hours.
+	Today DateLiteral = "TODAY"
+	// Tomorrow starts at 12:00:00 AM the day after and continues for 24 hours.
+	Tomorrow DateLiteral = "TOMORROW"
+	// LastWeek is the week before the current week.
+	LastWeek DateLiteral = "LAST_WEEK"
+	// ThisWeek is the current week.
+	ThisWeek DateLiteral = "THIS_WEEK"
+	// NextWeek is the week after the current week.
+	NextWeek DateLiteral = "NEXT_WEEK"
+	// LastMonth is the month before the current month.
+	LastMonth DateLiteral = "LAST_MONTH"
+	// ThisMonth is the current month.
+	ThisMonth DateLiteral = "THIS_MONTH"
+	// NextMonth is the month after the current month.
+	NextMonth DateLiteral = "NEXT_MONTH"
+	// Last90Days is the last 90 days, including the current day.
+	Last90Days DateLiteral = "LAST_90_DAYS"
+	// Next90Days is the next 90 days, starting the day after the current day.
+	Next90Days DateLiteral = "NEXT_90_DAYS"
+	// LastQuarter is the quarter before the current quarter.
+	LastQuarter DateLiteral = "LAST_QUARTER"
+	// ThisQuarter is the current quarter.
+	ThisQuarter DateLiteral = "THIS_QUARTER"
+	// NextQuarter is the quarter after the current quarter.
+	NextQuarter DateLiteral = "NEXT_QUARTER"
+	// LastYear is the year before the current year.
+	LastYear DateLiteral = "LAST_YEAR"
+	// ThisYear is the current year.
+	ThisYear DateLiteral = "THIS_YEAR"
+	// NextYear is the year after the current year.
+	NextYear DateLiteral = "NEXT_YEAR"
+	// LastFiscalQuarter is the fiscal quarter before the current fiscal quarter.
+	LastFiscalQuarter DateLiteral = "LAST_FISCAL_QUARTER"
+	// ThisFiscalQuarter is the current fiscal quarter.
+	ThisFiscalQuarter DateLiteral = "THIS_FISCAL_QUARTER"
+	// NextFiscalQuarter is the fiscal quarter after the current fiscal quarter.
+	NextFiscalQuarter DateLiteral = "NEXT_FISCAL_QUARTER"
+	// LastFiscalYear is the fiscal year before the current fiscal year.
+	LastFiscalYear DateLiteral = "LAST_FISCAL_YEAR"
+	// ThisFiscalYear is the current fiscal year.
+	ThisFiscalYear DateLiteral = "THIS_FISCAL_YEAR"
+	// NextFiscalYear is the fiscal year after the current fiscal year.
+	NextFiscalYear DateLiteral = "NEXT_FISCAL_YEAR"
+)
+
+// DateLiteralN is a relative date literal that takes a number of days, weeks,
+// months, quarters or years.  Use N to form the date literal.
+type DateLiteralN string
+
+const (
+	// LastNDays is the last n days, including the current day.
+	LastNDays DateLiteralN = "LAST_N_DAYS"
+	// NextNDays is the next n days, not including the current day.
+	NextNDays DateLiteralN = "NEXT_N_DAYS"
+	// NDaysAgo is the day n days ago.
+	NDaysAgo DateLiteralN = "N_DAYS_AGO"
+	// LastNWeeks is the last n weeks, not including the current week.
+	LastNWeeks DateLiteralN = "LAST_N_WEEKS"
+	// NextNWeeks is the next n weeks, not including the current week.
+	NextNWeeks DateLiteralN = "NEXT_N_WEEKS"
+	// NWeeksAgo is the week n weeks ago.
+	NWeeksAgo DateLiteralN = "N_WEEKS_AGO"
+	// LastNMonths is the last n months, not including the current month.
+	LastNMonths DateLiteralN = "LAST_N_MONTHS"
+	// NextNMonths is the next n months, not including the current month.
+	NextNMonths DateLiteralN = "NEXT_N_MONTHS"
+	// NMonthsAgo is the month n months ago.
+	NMonthsAgo DateLiteralN = "N_MONTHS_AGO"
+	// LastNQuarters is the last n quarters, not including the current quarter.
+	LastNQuarters DateLiteralN = "LAST_N_QUARTERS"
+	// NextNQuarters is the next n quarters, not including the current quarter.
+	NextNQuarters DateLiteralN = "NEXT_N_QUARTERS"
+	// NQuartersAgo is the quarter n quarters ago.
+	NQuartersAgo DateLiteralN = "N_QUARTERS_AGO"
+	// LastNYears is the last n years, not including the current year.
+	LastNYears DateLiteralN = "LAST_N_YEARS"
+	// NextNYears is the next n years, not including the current year.
+	NextNYears DateLiteralN = "NEXT_N_YEARS"
+	// NYearsAgo is the year n years ago.
+	NYearsAgo DateLiteralN = "N_YEARS_AGO"
+	// LastNFiscalQuarters is the last n fiscal quarters, not including the current fiscal quarter.
+	LastNFiscalQuarters DateLiteralN = "LAST_N_FISCAL_QUARTERS"
+	// NextNFiscalQuarters is the next n fiscal quarters, not including the current fiscal quarter.
+	NextNFiscalQuarters DateLiteralN = "NEXT_N_FISCAL_QUARTERS"
+	// NFiscalQuartersAgo is the fiscal quarter n fiscal quarters ago.
+	NFiscalQuartersAgo DateLiteralN = "N_FISCAL_QUARTERS_AGO"
+	// LastNFiscalYears is the last n fiscal years, not including the current fiscal year.
+	LastNFiscalYears DateLiteralN = "LAST_N_FISCAL_YEARS"
+	// NextNFiscalYears is the next n fiscal years, not including the current fiscal year.
+	NextNFiscalYears DateLiteralN = "NEXT_N_FISCAL_YEARS"
+	// NFiscalYearsAgo is the fiscal year n fiscal years ago.
+	NFiscalYearsAgo DateLiteralN = "N_FISCAL_YEARS_AGO"
+)
+
+// N forms the date literal with the number, like LAST_N_DAYS:30.  A negative
+// number forms a literal that is not valid.
+func (d DateLiteralN) N(n int) DateLiteral {
+	return DateLiteral(fmt.Sprintf("%s:%d", string(d), n))
+}
+
+// valid returns true if the date literal only has the characters of a date
+// literal, so that it can not change the query.
+func (d DateLiteral) valid() bool {
+	if d == "" {
+		return false
+	}
+	for _, r := range d {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// CalendarMonth forms the CALENDAR_MONTH date function of the field.
+func CalendarMonth(field string) string {
+	return aggregate("CALENDAR_MONTH", field)
+}
+
+// CalendarQuarter forms the CALENDAR_QUARTER date function of the field.
+func CalendarQuarter(field string) string {
+	return aggregate("CALENDAR_QUARTER", field)
+}
+
+// CalendarYear forms the CALENDAR_YEAR date function of the field.
+func CalendarYear(field string) string {
+	return aggregate("CALENDAR_YEAR", field)
+}
+
+// DayInMonth forms the DAY_IN_MONTH date function of the field.
+func DayInMonth(field string) string {
+	return aggregate("DAY_IN_MONTH", field)
+}
+
+// DayInWeek forms the DAY_IN_WEEK date function of the field, where Sunday is 1.
+func DayInWeek(field string) string {
+	return aggregate("DAY_IN_WEEK", field)
+}
+
+// DayInYear forms the DAY_IN_YEAR date function of the field.
+func DayInYear(field string) string {
+	return aggregate("DAY_IN_YEAR", field)
+}
+
+// DayOnly forms the DAY_ONLY date function of the date time field.
+func DayOnly(field string) string {
+	return aggregate("DAY_ONLY", field)
+}
+
+// FiscalMonth forms the FISCAL_MONTH date function of the field.
+func FiscalMonth(field string) string {
+	return aggregate("FISCAL_MONTH", field)
+}
+
+// FiscalQuarter forms the FISCAL_QUARTER date function of the field.
+func FiscalQuarter(field string) string {
+	return aggregate("FISCAL_QUARTER", field)
+}
+
+// FiscalYear forms the FISCAL_YEAR date function of the field.
+func FiscalYear(field string) string {
+	return aggregate("FISCAL_YEAR", field)
+}
+
+// HourInDay forms the HOUR_IN_DAY date function of the date time field.
+func HourInDay(field string) string {
+	return aggregate("HOUR_IN_DAY", field)
+}
+
+// WeekInMonth forms the WEEK_IN_MONTH date function of the field.
+func WeekInMonth(field string) string {
+	return aggregate("WEEK_IN_MONTH", field)
+}
+
+// WeekInYear forms the WEEK_IN_YEAR date function of the field.
+func WeekInYear(field string) string {
+	return aggregate("WEEK_IN_YEAR", field)
+}
+
+// ConvertTimezone forms the convertTimezone function of the date time field,
+// which converts the field to the user's time zone in a date function, like
+// HourInDay(ConvertTimezone("CreatedDate")).
+func ConvertTimezone(field string) string {
+	return aggregate("convertTimezone", field)
+}
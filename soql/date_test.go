@@ -0,0 +1,136 @@
+package soql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateLiteral(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Date",
+			value: Date(time.Date(2019, time.March, 26, 23, 30, 0, 0, time.UTC)),
+			want:  "2019-03-26",
+		},
+		{
+			name:  "Date Time",
+			value: DateTime(time.Date(2019, time.March, 26, 10, 30, 45, 0, time.FixedZone("EST", -5*60*60))),
+			want:  "2019-03-26T15:30:45Z",
+		},
+		{
+			name:  "Relative",
+			value: ThisFiscalQuarter,
+			want:  "THIS_FISCAL_QUARTER",
+		},
+		{
+			name:  "Relative N",
+			value: LastNDays.N(30),
+			want:  "LAST_N_DAYS:30",
+		},
+		{
+			name:  "Relative Set",
+			value: []DateLiteral{Today, Yesterday},
+			want:  "(TODAY,YESTERDAY)",
+		},
+		{
+			name:    "Negative N",
+			value:   NFiscalYearsAgo.N(-1),
+			wantErr: true,
+		},
+		{
+			name:    "Injection",
+			value:   DateLiteral("TODAY OR Name != null"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			value:   DateLiteral(""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Literal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Literal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Literal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "CalendarMonth", got: CalendarMonth("CreatedDate"), want: "CALENDAR_MONTH(CreatedDate)"},
+		{name: "CalendarQuarter", got: CalendarQuarter("CreatedDate"), want: "CALENDAR_QUARTER(CreatedDate)"},
+		{name: "CalendarYear", got: CalendarYear("CreatedDate"), want: "CALENDAR_YEAR(CreatedDate)"},
+		{name: "DayInMonth", got: DayInMonth("CreatedDate"), want: "DAY_IN_MONTH(CreatedDate)"},
+		{name: "DayInWeek", got: DayInWeek("CreatedDate"), want: "DAY_IN_WEEK(CreatedDate)"},
+		{name: "DayInYear", got: DayInYear("CreatedDate"), want: "DAY_IN_YEAR(CreatedDate)"},
+		{name: "DayOnly", got: DayOnly("CreatedDate"), want: "DAY_ONLY(CreatedDate)"},
+		{name: "FiscalMonth", got: FiscalMonth("CloseDate"), want: "FISCAL_MONTH(CloseDate)"},
+		{name: "FiscalQuarter", got: FiscalQuarter("CloseDate"), want: "FISCAL_QUARTER(CloseDate)"},
+		{name: "FiscalYear", got: FiscalYear("CloseDate"), want: "FISCAL_YEAR(CloseDate)"},
+		{name: "HourInDay", got: HourInDay(ConvertTimezone("CreatedDate")), want: "HOUR_IN_DAY(convertTimezone(CreatedDate))"},
+		{name: "WeekInMonth", got: WeekInMonth("CreatedDate"), want: "WEEK_IN_MONTH(CreatedDate)"},
+		{name: "WeekInYear", got: WeekInYear("CreatedDate"), want: "WEEK_IN_YEAR(CreatedDate)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_FormatDate(t *testing.T) {
+	year, err := WhereEquals(CalendarYear("CreatedDate"), 2024)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	closed, err := WhereGreaterThan("CloseDate", Date(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)), true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	recent, err := WhereEquals("LastModifiedDate", LastNDays.N(30))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	groupBy, err := NewGroupBy(GroupByFields, CalendarMonth("CreatedDate"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	query, err := NewQuery(QueryInput{
+		ObjectType: "Opportunity",
+		FieldList: []string{
+			CalendarMonth("CreatedDate"),
+			Alias(Sum("Amount"), "total"),
+		},
+		Where:   And(year, closed, recent),
+		GroupBy: groupBy,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := query.Format()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	want := "SELECT CALENDAR_MONTH(CreatedDate),SUM(Amount) total FROM Opportunity WHERE CALENDAR_YEAR(CreatedDate) = 2024 AND CloseDate >= 2024-01-01 AND LastModifiedDate = LAST_N_DAYS:30 GROUP BY CALENDAR_MONTH(CreatedDate)"
+	if got != want {
+		t.Errorf("Query.Format() = %v, want %v", got, want)
+	}
+}
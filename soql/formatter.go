@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 )
 
 // QueryInput is used to provide SOQL inputs.
@@ -15,21 +14,39 @@ import (
 //
 // SubQuery is the inner query
 //
+// TypeOf is the polymorphic field selection
+//
+// Scope is the SOQL using scope filter
+//
 // Where is the SOQL where cause
 //
+// With is the SOQL with filter, like SECURITY_ENFORCED or a data category
+//
+// GroupBy is the SOQL grouping of an aggregate query
+//
+// Having is the SOQL having expression of the grouping
+//
 // Order is the SOQL ordering
 //
 // Limit is the SOQL record limit
 //
 // Offset is the SOQL record offset
+//
+// For is the SOQL record tracking or locking, like FOR VIEW or FOR UPDATE
 type QueryInput struct {
 	FieldList  []string
 	ObjectType string
 	SubQuery   []QueryFormatter
+	TypeOf     []*TypeOf
+	Scope      FilterScope
 	Where      WhereClauser
+	With       Wither
+	GroupBy    Grouper
+	Having     WhereExpression
 	Order      Orderer
 	Limit      int
 	Offset     int
+	For        ForType
 }
 
 // Query is the struture used to build a SOQL query.
@@ -37,10 +54,16 @@ type Query struct {
 	fieldList  []string
 	objectType string
 	subQuery   []QueryFormatter
+	typeOf     []*TypeOf
+	scope      FilterScope
 	where      WhereClauser
+	with       Wither
+	groupBy    Grouper
+	having     WhereExpression
 	order      Orderer
 	limit      int
 	offset     int
+	forType    ForType
 }
 
 // QueryFormatter is the interface to return the SOQL query.
@@ -56,7 +79,7 @@ func NewQuery(input QueryInput) (*Query, error) {
 	if input.ObjectType == "" {
 		return nil, errors.New("builder: object type can not be an empty string")
 	}
-	if len(input.FieldList) == 0 {
+	if len(input.FieldList) == 0 && len(input.TypeOf) == 0 {
 		return nil, errors.New("builder: field list can not be empty")
 	}
 
@@ -64,10 +87,16 @@ func NewQuery(input QueryInput) (*Query, error) {
 		objectType: input.ObjectType,
 		fieldList:  input.FieldList,
 		subQuery:   input.SubQuery,
+		typeOf:     input.TypeOf,
+		scope:      input.Scope,
 		where:      input.Where,
+		with:       input.With,
+		groupBy:    input.GroupBy,
+		having:     input.Having,
 		order:      input.Order,
 		limit:      input.Limit,
 		offset:     input.Offset,
+		forType:    input.For,
 	}, nil
 }
 
@@ -77,25 +106,67 @@ func (b *Query) Format() (string, error) {
 	if b.objectType == "" {
 		return "", errors.New("builder: object type can not be an empty string")
 	}
-	if len(b.fieldList) == 0 {
+	if len(b.fieldList) == 0 && len(b.typeOf) == 0 {
 		return "", errors.New("builder: field list must be have fields present")
 	}
 
-	soql := "SELECT " + strings.Join(b.fieldList, ",")
+	selects := append([]string{}, b.fieldList...)
 	if b.subQuery != nil {
 		for _, query := range b.subQuery {
 			var sub string
 			var err error
 			if sub, err = query.Format(); err == nil {
-				soql += fmt.Sprintf(",(%s)", sub)
+				selects = append(selects, fmt.Sprintf("(%s)", sub))
 			} else {
 				return "", err
 			}
 		}
 	}
+	for _, typeOf := range b.typeOf {
+		if typeOf == nil {
+			return "", errors.New("builder: typeof can not be nil")
+		}
+		selection, err := typeOf.Format()
+		if err != nil {
+			return "", err
+		}
+		selects = append(selects, selection)
+	}
+	if err := b.checkFields(); err != nil {
+		return "", err
+	}
+	soql := "SELECT " + strings.Join(selects, ",")
 	soql += " FROM " + b.objectType
+	if b.scope != "" {
+		scope, err := b.scope.Scope()
+		if err != nil {
+			return "", err
+		}
+		soql += " " + scope
+	}
 	if b.where != nil {
-		soql += " " + b.where.Clause()
+		if clause := b.where.Clause(); clause != "" {
+			soql += " " + clause
+		}
+	}
+	if b.with != nil {
+		with, err := b.with.With()
+		if err != nil {
+			return "", err
+		}
+		soql += " " + with
+	}
+	if b.groupBy != nil {
+		groupBy, err := b.groupBy.GroupBy()
+		if err != nil {
+			return "", err
+		}
+		soql += " " + groupBy
+	}
+	if b.having != nil {
+		if having := b.having.Expression(); having != "" {
+			soql += " HAVING " + having
+		}
 	}
 	if b.order != nil {
 		order, err := b.order.Order()
@@ -111,10 +182,36 @@ func (b *Query) Format() (string, error) {
 	if b.offset > 0 {
 		soql += fmt.Sprintf(" OFFSET %d", b.offset)
 	}
+	if b.forType != "" {
+		if b.forType == ForUpdate && b.order != nil {
+			return "", errors.New("builder: FOR UPDATE can not be used with ORDER BY")
+		}
+		forType, err := b.forType.For()
+		if err != nil {
+			return "", err
+		}
+		soql += " " + forType
+	}
 	return soql, nil
 }
 
-// WhereClause is the structure that will contain a SOQL where clause.
+// checkFields checks that the FIELDS(ALL) and FIELDS(CUSTOM) selections have a
+// limit of at most 200 records, which Salesforce requires.
+func (b *Query) checkFields() error {
+	for _, field := range b.fieldList {
+		switch strings.ToUpper(strings.ReplaceAll(field, " ", "")) {
+		case Fields(FieldsAll), Fields(FieldsCustom):
+			if b.limit <= 0 || b.limit > fieldsLimit {
+				return fmt.Errorf("builder: %s requires a limit of at most %d", field, fieldsLimit)
+			}
+		}
+	}
+	return nil
+}
+
+// WhereClause is the structure that will contain a SOQL where clause.  Use
+// And, Or and Not to combine the where clauses, so that the parentheses are
+// placed for the precedence.
 type WhereClause struct {
 	expression string
 }
@@ -129,7 +226,9 @@ type WhereClauser interface {
 	Clause() string
 }
 
-// WhereLike will form the LIKE expression.
+// WhereLike will form the LIKE expression.  The value is a pattern, where %
+// and _ are the wildcards, and is escaped.  Use EscapeLike to match the
+// wildcard characters.
 func WhereLike(field string, value string) (*WhereClause, error) {
 	if field == "" {
 		return nil, errors.New("soql where: field can not be empty")
@@ -138,12 +237,27 @@ func WhereLike(field string, value string) (*WhereClause, error) {
 		return nil, errors.New("soql where: value can not be empty")
 	}
 	return &WhereClause{
-		expression: fmt.Sprintf("%s LIKE '%s'", field, value),
+		expression: fmt.Sprintf("%s LIKE '%s'", field, escapePattern(value)),
+	}, nil
+}
+
+// WhereNotLike will form the NOT LIKE expression.  The value is a pattern, like
+// WhereLike.
+func WhereNotLike(field string, value string) (*WhereClause, error) {
+	if field == "" {
+		return nil, errors.New("soql where: field can not be empty")
+	}
+	if value == "" {
+		return nil, errors.New("soql where: value can not be empty")
+	}
+	return &WhereClause{
+		expression: fmt.Sprintf("NOT %s LIKE '%s'", field, escapePattern(value)),
 	}, nil
 }
 
-// WhereGreaterThan will form the greater or equal than expression.  If the value is a
-// string or boolean, an error is returned.
+// WhereGreaterThan will form the greater or equal than expression.  Strings are
+// compared in the field's sort order.  If the value is a boolean, an error is
+// returned.
 func WhereGreaterThan(field string, value interface{}, equals bool) (*WhereClause, error) {
 	if field == "" {
 		return nil, errors.New("soql where: field can not be empty")
@@ -151,15 +265,12 @@ func WhereGreaterThan(field string, value interface{}, equals bool) (*WhereClaus
 	if value == nil {
 		return nil, errors.New("soql where: value can not be nil")
 	}
-	var v string
-	switch value.(type) {
-	case string, bool:
-		return nil, errors.New("where greater than: value can not be a string or bool")
-	case time.Time:
-		date := value.(time.Time)
-		v = date.Format(time.RFC3339)
-	default:
-		v = fmt.Sprintf("%v", value)
+	if _, is := value.(bool); is {
+		return nil, errors.New("where greater than: value can not be a bool")
+	}
+	v, err := Literal(value)
+	if err != nil {
+		return nil, err
 	}
 
 	operator := ">"
@@ -172,8 +283,9 @@ func WhereGreaterThan(field string, value interface{}, equals bool) (*WhereClaus
 	}, nil
 }
 
-// WhereLessThan will form the less or equal than expression.  If the value is a
-// string or boolean, an error is returned.
+// WhereLessThan will form the less or equal than expression.  Strings are
+// compared in the field's sort order.  If the value is a boolean, an error is
+// returned.
 func WhereLessThan(field string, value interface{}, equals bool) (*WhereClause, error) {
 	if field == "" {
 		return nil, errors.New("soql where: field can not be empty")
@@ -181,15 +293,12 @@ func WhereLessThan(field string, value interface{}, equals bool) (*WhereClause,
 	if value == nil {
 		return nil, errors.New("soql where: value can not be nil")
 	}
-	var v string
-	switch value.(type) {
-	case string, bool:
-		return nil, errors.New("where less than: value can not be a string")
-	case time.Time:
-		date := value.(time.Time)
-		v = date.Format(time.RFC3339)
-	default:
-		v = fmt.Sprintf("%v", value)
+	if _, is := value.(bool); is {
+		return nil, errors.New("where less than: value can not be a bool")
+	}
+	v, err := Literal(value)
+	if err != nil {
+		return nil, err
 	}
 
 	operator := "<"
@@ -207,19 +316,9 @@ func WhereEquals(field string, value interface{}) (*WhereClause, error) {
 	if field == "" {
 		return nil, errors.New("soql where: field can not be empty")
 	}
-	var v string
-	if value != nil {
-		switch value.(type) {
-		case string:
-			v = fmt.Sprintf("'%s'", value.(string))
-		case time.Time:
-			date := value.(time.Time)
-			v = date.Format(time.RFC3339)
-		default:
-			v = fmt.Sprintf("%v", value)
-		}
-	} else {
-		v = "null"
+	v, err := Literal(value)
+	if err != nil {
+		return nil, err
 	}
 
 	return &WhereClause{
@@ -232,19 +331,9 @@ func WhereNotEquals(field string, value interface{}) (*WhereClause, error) {
 	if field == "" {
 		return nil, errors.New("soql where: field can not be empty")
 	}
-	var v string
-	if value != nil {
-		switch value.(type) {
-		case string:
-			v = fmt.Sprintf("'%s'", value.(string))
-		case time.Time:
-			date := value.(time.Time)
-			v = date.Format(time.RFC3339)
-		default:
-			v = fmt.Sprintf("%v", value)
-		}
-	} else {
-		v = "null"
+	v, err := Literal(value)
+	if err != nil {
+		return nil, err
 	}
 
 	return &WhereClause{
@@ -262,17 +351,14 @@ func WhereIn(field string, values []interface{}) (*WhereClause, error) {
 	}
 	set := make([]string, len(values))
 	for idx, value := range values {
-		switch value.(type) {
-		case string:
-			set[idx] = fmt.Sprintf("'%s'", value.(string))
-		case bool:
+		if _, is := value.(bool); is {
 			return nil, errors.New("where in: boolean is not a value set value")
-		case time.Time:
-			date := value.(time.Time)
-			set[idx] = date.Format(time.RFC3339)
-		default:
-			set[idx] = fmt.Sprintf("%v", value)
 		}
+		literal, err := setLiteral(value)
+		if err != nil {
+			return nil, err
+		}
+		set[idx] = literal
 	}
 
 	return &WhereClause{
@@ -290,17 +376,14 @@ func WhereNotIn(field string, values []interface{}) (*WhereClause, error) {
 	}
 	set := make([]string, len(values))
 	for idx, value := range values {
-		switch value.(type) {
-		case string:
-			set[idx] = fmt.Sprintf("'%s'", value.(string))
-		case bool:
+		if _, is := value.(bool); is {
 			return nil, errors.New("where not in: boolean is not a value set value")
-		case time.Time:
-			date := value.(time.Time)
-			set[idx] = date.Format(time.RFC3339)
-		default:
-			set[idx] = fmt.Sprintf("%v", value)
 		}
+		literal, err := setLiteral(value)
+		if err != nil {
+			return nil, err
+		}
+		set[idx] = literal
 	}
 
 	return &WhereClause{
@@ -308,6 +391,45 @@ func WhereNotIn(field string, values []interface{}) (*WhereClause, error) {
 	}, nil
 }
 
+// WhereIncludes forms the multi-select picklist includes expression.  Each
+// value is a set of selections joined by a semicolon, like "AAA;BBB", and the
+// field matches if it has all of the selections of any value.
+func WhereIncludes(field string, values []string) (*WhereClause, error) {
+	set, err := picklistSet("where includes", field, values)
+	if err != nil {
+		return nil, err
+	}
+	return &WhereClause{
+		expression: fmt.Sprintf("%s INCLUDES (%s)", field, set),
+	}, nil
+}
+
+// WhereExcludes forms the multi-select picklist excludes expression.  The
+// values are the same as WhereIncludes.
+func WhereExcludes(field string, values []string) (*WhereClause, error) {
+	set, err := picklistSet("where excludes", field, values)
+	if err != nil {
+		return nil, err
+	}
+	return &WhereClause{
+		expression: fmt.Sprintf("%s EXCLUDES (%s)", field, set),
+	}, nil
+}
+
+func picklistSet(where, field string, values []string) (string, error) {
+	if field == "" {
+		return "", errors.New("soql where: field can not be empty")
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("%s: values can not be empty", where)
+	}
+	set := make([]string, len(values))
+	for idx, value := range values {
+		set[idx] = fmt.Sprintf("'%s'", Escape(value))
+	}
+	return strings.Join(set, ","), nil
+}
+
 // Clause returns the where cluase.
 func (wc *WhereClause) Clause() string {
 	return fmt.Sprintf("WHERE %s", wc.expression)
@@ -396,7 +518,8 @@ func (o *OrderBy) NullOrdering(nulls OrderNulls) error {
 	return nil
 }
 
-// Order returns the order by SOQL string.
+// Order returns the order by SOQL string.  The result ordering and the null
+// ordering apply to the last field, so use OrderByFields to order each field.
 func (o *OrderBy) Order() (string, error) {
 	switch o.result {
 	case OrderAsc, OrderDesc:
@@ -410,3 +533,47 @@ func (o *OrderBy) Order() (string, error) {
 	}
 	return orderBy, nil
 }
+
+// OrderByFields is the ordering structure of the SOQL query, where each field
+// has its own result and null ordering.
+type OrderByFields struct {
+	fieldOrder []string
+}
+
+// NewOrderByFields creates an OrderByFields structure.
+func NewOrderByFields() *OrderByFields {
+	return &OrderByFields{}
+}
+
+// Add will place the field in the ordering.  The null ordering is optional and
+// can be an empty string.  If the field is empty or the orderings are not
+// valid, an error is returned.
+func (o *OrderByFields) Add(field string, result OrderResult, nulls OrderNulls) error {
+	if field == "" {
+		return errors.New("order by fields: field can not be empty")
+	}
+	switch result {
+	case OrderAsc, OrderDesc:
+	default:
+		return fmt.Errorf("order by fields: %s is not a valid result ordering type", string(result))
+	}
+	order := field + " " + string(result)
+	switch nulls {
+	case "":
+	case OrderNullsLast, OrderNullsFirst:
+		order += " " + string(nulls)
+	default:
+		return fmt.Errorf("order by fields: %s is not a valid null ordering type", string(nulls))
+	}
+	o.fieldOrder = append(o.fieldOrder, order)
+	return nil
+}
+
+// Order returns the order by SOQL string.  If there are no fields, an error
+// is returned.
+func (o *OrderByFields) Order() (string, error) {
+	if len(o.fieldOrder) == 0 {
+		return "", errors.New("order by fields: fields can not be empty")
+	}
+	return "ORDER BY " + strings.Join(o.fieldOrder, ","), nil
+}
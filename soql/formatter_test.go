@@ -294,6 +294,17 @@ func TestWhereLike(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Like Where Escaped",
+			args: args{
+				field: "Name",
+				value: `O'Brien\` + EscapeLike("100%_\\") + "%",
+			},
+			want: &WhereClause{
+				expression: `Name LIKE 'O\'Brien\\100\%\_\\%'`,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -325,10 +336,12 @@ func TestWhereGreaterThan(t *testing.T) {
 			name: "value is string",
 			args: args{
 				field: "Name",
-				value: "some string",
+				value: "M'c",
 			},
-			want:    nil,
-			wantErr: true,
+			want: &WhereClause{
+				expression: `Name > 'M\'c'`,
+			},
+			wantErr: false,
 		},
 		{
 			name: "value is boolean",
@@ -404,10 +417,12 @@ func TestWhereLessThan(t *testing.T) {
 			name: "value is string",
 			args: args{
 				field: "Name",
-				value: "some string",
+				value: "M'c",
 			},
-			want:    nil,
-			wantErr: true,
+			want: &WhereClause{
+				expression: `Name < 'M\'c'`,
+			},
+			wantErr: false,
 		},
 		{
 			name: "value is boolean",
@@ -489,6 +504,26 @@ func TestWhereEquals(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "string injection",
+			args: args{
+				field: "Name",
+				value: `x' OR Name != 'x`,
+			},
+			want: &WhereClause{
+				expression: `Name = 'x\' OR Name != \'x'`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported",
+			args: args{
+				field: "Name",
+				value: struct{}{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "boolean",
 			args: args{
@@ -697,6 +732,17 @@ func TestWhereIn(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Nested Set",
+			args: args{
+				field: "Name",
+				values: []interface{}{
+					[]string{"Yeah", "Yep"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1179,3 +1225,68 @@ func TestNewBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderByFields_Order(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		results []OrderResult
+		nulls   []OrderNulls
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Per Field",
+			fields:  []string{"Name", "CreatedDate"},
+			results: []OrderResult{OrderAsc, OrderDesc},
+			nulls:   []OrderNulls{OrderNullsLast, ""},
+			want:    "ORDER BY Name ASC NULLS LAST,CreatedDate DESC",
+		},
+		{
+			name:    "No Fields",
+			wantErr: true,
+		},
+		{
+			name:    "Empty Field",
+			fields:  []string{""},
+			results: []OrderResult{OrderAsc},
+			nulls:   []OrderNulls{""},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Result",
+			fields:  []string{"Name"},
+			results: []OrderResult{OrderResult("UP")},
+			nulls:   []OrderNulls{""},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Nulls",
+			fields:  []string{"Name"},
+			results: []OrderResult{OrderAsc},
+			nulls:   []OrderNulls{OrderNulls("NULLS MIDDLE")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewOrderByFields()
+			for idx, field := range tt.fields {
+				if err := o.Add(field, tt.results[idx], tt.nulls[idx]); err != nil {
+					if tt.wantErr == false {
+						t.Errorf("OrderByFields.Add() error = %v", err)
+					}
+					return
+				}
+			}
+			got, err := o.Order()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrderByFields.Order() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("OrderByFields.Order() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
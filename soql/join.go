@@ -0,0 +1,124 @@
+package soql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WhereInQuery forms the semi-join expression, where the field is in the
+// results of the query, like Id IN (SELECT AccountId FROM Opportunity).  The
+// field can not be a relationship field, like Account.Id.  The query must
+// select a single field, without a relationship, and can not have a subquery
+// of its own.  The field's type is not known, so Salesforce will return the
+// error when it is not an Id or reference field.
+func WhereInQuery(field string, query QueryFormatter) (*WhereClause, error) {
+	return whereJoin("where in query", field, "IN", query)
+}
+
+// WhereNotInQuery forms the anti-join expression, where the field is not in
+// the results of the query.  The query has the same rules as WhereInQuery.
+func WhereNotInQuery(field string, query QueryFormatter) (*WhereClause, error) {
+	return whereJoin("where not in query", field, "NOT IN", query)
+}
+
+func whereJoin(where, field, operator string, query QueryFormatter) (*WhereClause, error) {
+	switch {
+	case strings.TrimSpace(field) == "":
+		return nil, fmt.Errorf("%s: field can not be empty", where)
+	case strings.Contains(field, "."):
+		return nil, fmt.Errorf("%s: field can not be the relationship field %s", where, field)
+	}
+	if query == nil {
+		return nil, fmt.Errorf("%s: query can not be nil", where)
+	}
+	stmt, err := query.Format()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJoin(stmt); err != nil {
+		return nil, fmt.Errorf("%s: %w", where, err)
+	}
+	return &WhereClause{
+		expression: fmt.Sprintf("%s %s (%s)", field, operator, stmt),
+	}, nil
+}
+
+// checkJoin checks that the query selects a single field, without a
+// relationship, and that there are no subqueries in the query.
+func checkJoin(stmt string) error {
+	words := queryWords(stmt)
+	if len(words) == 0 || strings.EqualFold(words[0].word, "SELECT") == false {
+		return errors.New("query is not a SELECT statement")
+	}
+	from := -1
+	for _, word := range words[1:] {
+		switch {
+		case strings.EqualFold(word.word, "SELECT"):
+			return errors.New("query can not have a subquery")
+		case from == -1 && word.depth == 0 && strings.EqualFold(word.word, "FROM"):
+			from = word.index
+		}
+	}
+	if from == -1 {
+		return errors.New("query does not have a FROM")
+	}
+	fields := strings.TrimSpace(stmt[words[0].index+len(words[0].word) : from])
+	switch {
+	case fields == "" || strings.ContainsAny(fields, ",( \t\n\r"):
+		return fmt.Errorf("query must select a single field, not %s", fields)
+	case strings.Contains(fields, "."):
+		return fmt.Errorf("query can not select the relationship field %s", fields)
+	}
+	return nil
+}
+
+type queryWord struct {
+	word  string
+	index int
+	depth int
+}
+
+// queryWords returns the words of the query, outside of the string literals,
+// with the parentheses depth of each word.
+func queryWords(stmt string) []queryWord {
+	var words []queryWord
+	var depth int
+	start := -1
+	end := func(idx int) {
+		if start != -1 {
+			words = append(words, queryWord{
+				word:  stmt[start:idx],
+				index: start,
+				depth: depth,
+			})
+			start = -1
+		}
+	}
+	for idx := 0; idx < len(stmt); idx++ {
+		b := stmt[idx]
+		switch {
+		case b == '\'':
+			end(idx)
+			for idx++; idx < len(stmt) && stmt[idx] != '\''; idx++ {
+				if stmt[idx] == '\\' {
+					idx++
+				}
+			}
+		case b == '(':
+			end(idx)
+			depth++
+		case b == ')':
+			end(idx)
+			depth--
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == ',':
+			end(idx)
+		default:
+			if start == -1 {
+				start = idx
+			}
+		}
+	}
+	end(len(stmt))
+	return words
+}
@@ -0,0 +1,187 @@
+package soql
+
+import (
+	"testing"
+)
+
+func testJoinQuery(t *testing.T, input QueryInput) *Query {
+	query, err := NewQuery(input)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return query
+}
+
+func TestWhereInQuery(t *testing.T) {
+	stage, err := WhereEquals("StageName", "Closed (Won)")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	opportunities := testJoinQuery(t, QueryInput{
+		ObjectType: "Opportunity",
+		FieldList:  []string{"AccountId"},
+		Where:      stage,
+	})
+	inner, err := WhereInQuery("AccountId", opportunities)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	statement, err := NewStatement("SELECT Account__c FROM Invoice__c WHERE Name = 'SELECT'", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		query   QueryFormatter
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Semi Join",
+			field: "Id",
+			query: opportunities,
+			want:  "Id IN (SELECT AccountId FROM Opportunity WHERE StageName = 'Closed (Won)')",
+		},
+		{
+			name:  "Statement",
+			field: "Id",
+			query: statement,
+			want:  "Id IN (SELECT Account__c FROM Invoice__c WHERE Name = 'SELECT')",
+		},
+		{
+			name:    "No Field",
+			query:   opportunities,
+			wantErr: true,
+		},
+		{
+			name:    "Blank Field",
+			field:   "  ",
+			query:   opportunities,
+			wantErr: true,
+		},
+		{
+			name:    "Relationship Field",
+			field:   "Account.Id",
+			query:   opportunities,
+			wantErr: true,
+		},
+		{
+			name:    "No Query",
+			field:   "Id",
+			wantErr: true,
+		},
+		{
+			name:  "Many Fields",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Opportunity",
+				FieldList:  []string{"AccountId", "Name"},
+			}),
+			wantErr: true,
+		},
+		{
+			name:  "Field Type Left To Salesforce",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Opportunity",
+				FieldList:  []string{"Amount__c"},
+			}),
+			want: "Id IN (SELECT Amount__c FROM Opportunity)",
+		},
+		{
+			name:  "Leading Space",
+			field: "Id",
+			query: &Statement{query: "  SELECT AccountId FROM Opportunity"},
+			want:  "Id IN (  SELECT AccountId FROM Opportunity)",
+		},
+		{
+			name:  "Relationship",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Opportunity",
+				FieldList:  []string{"Account.Id"},
+			}),
+			wantErr: true,
+		},
+		{
+			name:  "Aggregate",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Opportunity",
+				FieldList:  []string{Count("AccountId")},
+			}),
+			wantErr: true,
+		},
+		{
+			name:  "Nested",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Contact",
+				FieldList:  []string{"AccountId"},
+				Where:      inner,
+			}),
+			wantErr: true,
+		},
+		{
+			name:  "Parent Child",
+			field: "Id",
+			query: testJoinQuery(t, QueryInput{
+				ObjectType: "Account",
+				FieldList:  []string{"Id"},
+				SubQuery: []QueryFormatter{
+					testJoinQuery(t, QueryInput{
+						ObjectType: "Contacts",
+						FieldList:  []string{"Id"},
+					}),
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "Not Select",
+			field:   "Id",
+			query:   &Statement{query: "DELETE FROM Account"},
+			wantErr: true,
+		},
+		{
+			name:    "No From",
+			field:   "Id",
+			query:   &Statement{query: "SELECT Id"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WhereInQuery(tt.field, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WhereInQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Expression() != tt.want {
+				t.Errorf("WhereInQuery() = %v, want %v", got.Expression(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereNotInQuery(t *testing.T) {
+	query := testJoinQuery(t, QueryInput{
+		ObjectType: "Opportunity",
+		FieldList:  []string{"AccountId"},
+	})
+	got, err := WhereNotInQuery("Id", query)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := "Id NOT IN (SELECT AccountId FROM Opportunity)"; got.Expression() != want {
+		t.Errorf("WhereNotInQuery() = %v, want %v", got.Expression(), want)
+	}
+	if _, err := WhereNotInQuery("Id", &Statement{query: "SELECT Id, Name FROM Account"}); err == nil {
+		t.Error("WhereNotInQuery() expected an error for a query with more than one field")
+	}
+}
@@ -0,0 +1,218 @@
+package soql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var literalEscapes = map[rune]string{
+	'\\': `\\`,
+	'\'': `\'`,
+	'"':  `\"`,
+	'\n': `\n`,
+	'\r': `\r`,
+	'\t': `\t`,
+	'\b': `\b`,
+	'\f': `\f`,
+}
+
+// Escape will escape the value, so that it can be placed in a quoted SOQL
+// string literal.  The quotes, backslashes and control characters are escaped.
+func Escape(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		if escaped, has := literalEscapes[r]; has {
+			builder.WriteString(escaped)
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// EscapeLike will escape the LIKE wildcard characters, % and _, so that the
+// value is matched as it is in a WhereLike pattern.
+func EscapeLike(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		switch r {
+		case '\\', '%', '_':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// escapePattern escapes a LIKE pattern.  The escaped wildcards and backslashes,
+// from EscapeLike, are kept as they are.
+func escapePattern(pattern string) string {
+	var builder strings.Builder
+	runes := []rune(pattern)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] == '\\' && idx+1 < len(runes) && strings.ContainsRune(`\%_`, runes[idx+1]) {
+			builder.WriteRune('\\')
+			builder.WriteRune(runes[idx+1])
+			idx++
+			continue
+		}
+		builder.WriteString(Escape(string(runes[idx])))
+	}
+	return builder.String()
+}
+
+// Literal will format the value as a SOQL literal.  Strings are quoted and
+// escaped, time.Time and DateTime are date times, Date is a date, DateLiteral
+// is a relative date and slices are a set of values, like ('a','b').  A nil
+// value is null.
+func Literal(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return "'" + Escape(v) + "'", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(DateTimeFormat), nil
+	case *time.Time:
+		if v == nil {
+			return "null", nil
+		}
+		return v.Format(DateTimeFormat), nil
+	case Date:
+		return time.Time(v).Format(DateFormat), nil
+	case DateTime:
+		return time.Time(v).UTC().Format(DateTimeFormat), nil
+	case DateLiteral:
+		if v.valid() == false {
+			return "", fmt.Errorf("soql literal: %s is not a date literal", string(v))
+		}
+		return string(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return "'" + Escape(rv.String()) + "'", nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits()), nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "null", nil
+		}
+		return Literal(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return "", errors.New("soql literal: set can not be empty")
+		}
+		set := make([]string, rv.Len())
+		for idx := 0; idx < rv.Len(); idx++ {
+			literal, err := setLiteral(rv.Index(idx).Interface())
+			if err != nil {
+				return "", err
+			}
+			set[idx] = literal
+		}
+		return "(" + strings.Join(set, ",") + ")", nil
+	}
+	return "", fmt.Errorf("soql literal: %T is not a supported value", value)
+}
+
+// setLiteral will format the value of a set as a SOQL literal.  If the value
+// is a set or a map, even through an interface or a pointer, an error is
+// returned.
+func setLiteral(value interface{}) (string, error) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && rv.IsNil() == false {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "", errors.New("soql literal: set can not contain a set")
+	}
+	return Literal(value)
+}
+
+// Bind will replace the :name bind parameters of the query with the literal
+// of the parameter's value.  The parameters in string literals are not
+// replaced.  If a parameter is not bound, an error is returned.
+func Bind(query string, parameters map[string]interface{}) (string, error) {
+	var builder strings.Builder
+	runes := []rune(query)
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case r == '\'':
+			start := idx
+			for idx++; idx < len(runes) && runes[idx] != '\''; idx++ {
+				if runes[idx] == '\\' {
+					idx++
+				}
+			}
+			if idx >= len(runes) {
+				return "", errors.New("soql bind: unterminated string literal")
+			}
+			builder.WriteString(string(runes[start : idx+1]))
+		case r == ':' && idx+1 < len(runes) && isBindStart(runes[idx+1]):
+			start := idx + 1
+			for idx++; idx+1 < len(runes) && isBindPart(runes[idx+1]); idx++ {
+			}
+			name := string(runes[start : idx+1])
+			value, has := parameters[name]
+			if has == false {
+				return "", fmt.Errorf("soql bind: :%s is not bound", name)
+			}
+			literal, err := Literal(value)
+			if err != nil {
+				return "", fmt.Errorf("soql bind: :%s %w", name, err)
+			}
+			builder.WriteString(literal)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String(), nil
+}
+
+func isBindStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isBindPart(r rune) bool {
+	return isBindStart(r) || unicode.IsDigit(r)
+}
+
+// Statement is a SOQL query with :name bind parameters.
+type Statement struct {
+	query      string
+	parameters map[string]interface{}
+}
+
+// NewStatement creates a statement with the bind parameters.  If the query is
+// an empty string, an error is returned.
+func NewStatement(query string, parameters map[string]interface{}) (*Statement, error) {
+	if query == "" {
+		return nil, errors.New("soql statement: query can not be an empty string")
+	}
+	return &Statement{
+		query:      query,
+		parameters: parameters,
+	}, nil
+}
+
+// Format returns the SOQL query with the bind parameters replaced.
+func (s *Statement) Format() (string, error) {
+	return Bind(s.query, s.parameters)
+}
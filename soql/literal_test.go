@@ -0,0 +1,258 @@
+package soql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "Plain",
+			value: "Acme",
+			want:  "Acme",
+		},
+		{
+			name:  "Quotes",
+			value: `O'Brien "Jr"`,
+			want:  `O\'Brien \"Jr\"`,
+		},
+		{
+			name:  "Backslash",
+			value: `C:\temp\'`,
+			want:  `C:\\temp\\\'`,
+		},
+		{
+			name:  "Control",
+			value: "line\nbreak\ttab",
+			want:  `line\nbreak\ttab`,
+		},
+		{
+			name:  "Wildcards",
+			value: "100%_",
+			want:  "100%_",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Escape(tt.value); got != tt.want {
+				t.Errorf("Escape() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got := EscapeLike(`50%_off\`); got != `50\%\_off\\` {
+		t.Errorf("EscapeLike() = %v, want %v", got, `50\%\_off\\`)
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	name := "Acme"
+	var nilTime *time.Time
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Null",
+			value: nil,
+			want:  "null",
+		},
+		{
+			name:  "String",
+			value: "it's",
+			want:  `'it\'s'`,
+		},
+		{
+			name:  "String Pointer",
+			value: &name,
+			want:  "'Acme'",
+		},
+		{
+			name:  "Bool",
+			value: false,
+			want:  "false",
+		},
+		{
+			name:  "Int",
+			value: int64(-42),
+			want:  "-42",
+		},
+		{
+			name:  "Uint",
+			value: uint8(7),
+			want:  "7",
+		},
+		{
+			name:  "Float",
+			value: 1234.5,
+			want:  "1234.5",
+		},
+		{
+			name:  "Float32",
+			value: float32(0.1),
+			want:  "0.1",
+		},
+		{
+			name:  "Date Time",
+			value: time.Date(2019, time.March, 26, 15, 30, 45, 0, time.UTC),
+			want:  "2019-03-26T15:30:45Z",
+		},
+		{
+			name:  "Nil Date Time",
+			value: nilTime,
+			want:  "null",
+		},
+		{
+			name:  "Date",
+			value: Date(time.Date(2019, time.March, 26, 15, 30, 45, 0, time.UTC)),
+			want:  "2019-03-26",
+		},
+		{
+			name:  "ID Set",
+			value: []string{"0012E00001q0KijQAE", "0012E00001q0KikQAE"},
+			want:  "('0012E00001q0KijQAE','0012E00001q0KikQAE')",
+		},
+		{
+			name:  "Mixed Set",
+			value: []interface{}{1, "two", nil},
+			want:  "(1,'two',null)",
+		},
+		{
+			name:    "Empty Set",
+			value:   []string{},
+			wantErr: true,
+		},
+		{
+			name:    "Nested Set",
+			value:   [][]string{{"a"}},
+			wantErr: true,
+		},
+		{
+			name:    "Nested Interface Set",
+			value:   []interface{}{"a", []string{"b"}},
+			wantErr: true,
+		},
+		{
+			name:    "Set Of Maps",
+			value:   []interface{}{map[string]string{}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported",
+			value:   map[string]string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Literal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Literal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Literal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		parameters map[string]interface{}
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:  "Passing",
+			query: "SELECT Id FROM Account WHERE Name = :name AND CreatedDate > :since AND Id IN :ids AND Active__c = :active AND Parent.Id = :parent",
+			parameters: map[string]interface{}{
+				"name":   "O'Brien",
+				"since":  time.Date(2019, time.March, 26, 0, 0, 0, 0, time.UTC),
+				"ids":    []string{"001A", "001B"},
+				"active": true,
+				"parent": nil,
+			},
+			want: `SELECT Id FROM Account WHERE Name = 'O\'Brien' AND CreatedDate > 2019-03-26T00:00:00Z AND Id IN ('001A','001B') AND Active__c = true AND Parent.Id = null`,
+		},
+		{
+			name:  "String Literal",
+			query: `SELECT Id FROM Account WHERE Name = 'a :name \' :name' AND Id = :id`,
+			parameters: map[string]interface{}{
+				"id": "001A",
+			},
+			want: `SELECT Id FROM Account WHERE Name = 'a :name \' :name' AND Id = '001A'`,
+		},
+		{
+			name:  "Repeated",
+			query: "SELECT Id FROM Account WHERE Name = :name OR Site = :name",
+			parameters: map[string]interface{}{
+				"name": "x",
+			},
+			want: "SELECT Id FROM Account WHERE Name = 'x' OR Site = 'x'",
+		},
+		{
+			name:       "Not Bound",
+			query:      "SELECT Id FROM Account WHERE Name = :name",
+			parameters: map[string]interface{}{},
+			wantErr:    true,
+		},
+		{
+			name:  "Unsupported Value",
+			query: "SELECT Id FROM Account WHERE Name = :name",
+			parameters: map[string]interface{}{
+				"name": struct{}{},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Unterminated",
+			query:      "SELECT Id FROM Account WHERE Name = 'abc",
+			parameters: map[string]interface{}{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bind(tt.query, tt.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Bind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatement_Format(t *testing.T) {
+	if _, err := NewStatement("", nil); err == nil {
+		t.Error("NewStatement() expected an error for an empty query")
+	}
+	statement, err := NewStatement("SELECT Id FROM Contact WHERE Birthdate = :birthdate LIMIT :limit", map[string]interface{}{
+		"birthdate": Date(time.Date(1980, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		"limit":     10,
+	})
+	if err != nil {
+		t.Fatalf("NewStatement() error = %v", err)
+	}
+	got, err := statement.Format()
+	if err != nil {
+		t.Fatalf("Statement.Format() error = %v", err)
+	}
+	if want := "SELECT Id FROM Contact WHERE Birthdate = 1980-02-29 LIMIT 10"; got != want {
+		t.Errorf("Statement.Format() = %v, want %v", got, want)
+	}
+}
@@ -1,9 +1,9 @@
 package soql
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -34,11 +34,17 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 // be the result of the query.  The all parameter is for querying all records,
 // which include deleted records that are in the recycle bin.
 func (r *Resource) Query(querier QueryFormatter, all bool) (*QueryResult, error) {
+	return r.QueryWithContext(context.Background(), querier, all)
+}
+
+// QueryWithContext will call out to the Salesforce org for a SOQL using the context
+// for the request.
+func (r *Resource) QueryWithContext(ctx context.Context, querier QueryFormatter, all bool) (*QueryResult, error) {
 	if querier == nil {
 		return nil, errors.New("soql resource query: querier can not be nil")
 	}
 
-	request, err := r.queryRequest(querier, all)
+	request, err := r.queryRequest(ctx, querier, all)
 	if err != nil {
 		return nil, err
 	}
@@ -56,9 +62,9 @@ func (r *Resource) Query(querier QueryFormatter, all bool) (*QueryResult, error)
 	return result, nil
 }
 
-func (r *Resource) next(recordURL string) (*QueryResult, error) {
+func (r *Resource) next(ctx context.Context, recordURL string) (*QueryResult, error) {
 	queryURL := r.session.InstanceURL() + recordURL
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -79,7 +85,7 @@ func (r *Resource) next(recordURL string) (*QueryResult, error) {
 
 	return result, nil
 }
-func (r *Resource) queryRequest(querier QueryFormatter, all bool) (*http.Request, error) {
+func (r *Resource) queryRequest(ctx context.Context, querier QueryFormatter, all bool) (*http.Request, error) {
 	query, err := querier.Format()
 	if err != nil {
 		return nil, err
@@ -96,7 +102,7 @@ func (r *Resource) queryRequest(querier QueryFormatter, all bool) (*http.Request
 	form.Add("q", query)
 	queryURL += "?" + form.Encode()
 
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -115,21 +121,11 @@ func (r *Resource) queryResponse(request *http.Request) (queryResponse, error) {
 	}
 
 	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		var queryErrs []sfdc.Error
-		err = decoder.Decode(&queryErrs)
-		var errMsg error
-		if err == nil {
-			for _, queryErr := range queryErrs {
-				errMsg = fmt.Errorf("insert response err: %s: %s", queryErr.ErrorCode, queryErr.Message)
-			}
-		} else {
-			errMsg = fmt.Errorf("insert response err: %d %s", response.StatusCode, response.Status)
-		}
-
-		return queryResponse{}, errMsg
+		return queryResponse{}, sfdc.NewAPIError(response)
 	}
 
 	var resp queryResponse
@@ -1,12 +1,15 @@
 package soql
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/g8rswimmer/go-sfdc"
 	"github.com/g8rswimmer/go-sfdc/session"
 )
 
@@ -360,7 +363,7 @@ func TestResource_next(t *testing.T) {
 			r := &Resource{
 				session: tt.fields.session,
 			}
-			got, err := r.next(tt.args.recordURL)
+			got, err := r.next(context.Background(), tt.args.recordURL)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Resource.next() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -374,3 +377,39 @@ func TestResource_next(t *testing.T) {
 		})
 	}
 }
+
+func TestResource_Query_APIError(t *testing.T) {
+	resource := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				resp := `
+				[
+					{
+						"message" : "unexpected token: FORM",
+						"errorCode" : "MALFORMED_QUERY"
+					}
+				]`
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "400 Bad Request",
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+					Request:    req,
+				}
+			}),
+		},
+	}
+
+	_, err := resource.Query(&mockQuerier{stmt: "SELECT Name FORM Account"}, false)
+	if errors.Is(err, sfdc.ErrMalformedQuery) == false {
+		t.Fatalf("Resource.Query() error = %v, want %v", err, sfdc.ErrMalformedQuery)
+	}
+	var apiErr *sfdc.APIError
+	if errors.As(err, &apiErr) == false {
+		t.Fatalf("Resource.Query() error = %v, want an API error", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Method != http.MethodGet || len(apiErr.Errors) != 1 {
+		t.Errorf("Resource.Query() error = %+v", apiErr)
+	}
+}
@@ -54,6 +54,20 @@ func (rec *QueryRecord) Subresult(sub string) (*QueryResult, bool) {
 	return result, has
 }
 
+// Unmarshal decodes the record, its look ups and the records of its inner
+// queries into the tagged struct pointed to by v.  See sfdc.Unmarshal.
+func (rec *QueryRecord) Unmarshal(v interface{}) error {
+	children := make(map[string][]*sfdc.Record)
+	for name, result := range rec.subresults {
+		records := make([]*sfdc.Record, len(result.Records()))
+		for idx, record := range result.Records() {
+			records[idx] = record.Record()
+		}
+		children[name] = records
+	}
+	return sfdc.UnmarshalChildren(rec.record, children, v)
+}
+
 func isSubQuery(jsonMap map[string]interface{}) bool {
 	if _, has := jsonMap["totalSize"]; has == false {
 		return false
@@ -522,3 +522,62 @@ func TestQueryRecord_Subresult(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRecord_Unmarshal(t *testing.T) {
+	type contact struct {
+		LastName string
+	}
+	type account struct {
+		Name     string
+		Owner    string    `sfdc:"Owner.Name"`
+		Contacts []contact `sfdc:"Contacts"`
+	}
+	rec, err := newQueryRecord(map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"type": "Account",
+		},
+		"Name": "Test 1",
+		"Owner": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"type": "User",
+			},
+			"Name": "Owner 1",
+		},
+		"Contacts": map[string]interface{}{
+			"done":      true,
+			"totalSize": float64(2),
+			"records": []interface{}{
+				map[string]interface{}{
+					"attributes": map[string]interface{}{
+						"type": "Contact",
+					},
+					"LastName": "Test 1",
+				},
+				map[string]interface{}{
+					"attributes": map[string]interface{}{
+						"type": "Contact",
+					},
+					"LastName": "Test 2",
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("newQueryRecord() error = %v", err)
+	}
+	var got account
+	if err := rec.Unmarshal(&got); err != nil {
+		t.Fatalf("QueryRecord.Unmarshal() error = %v", err)
+	}
+	want := account{
+		Name:  "Test 1",
+		Owner: "Owner 1",
+		Contacts: []contact{
+			{LastName: "Test 1"},
+			{LastName: "Test 2"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("QueryRecord.Unmarshal() = %v, want %v", got, want)
+	}
+}
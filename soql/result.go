@@ -1,6 +1,9 @@
 package soql
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 // QueryResult is returned from the SOQL query.  This will
 // allow for retrieving all of the records and query the
@@ -52,8 +55,17 @@ func (result *QueryResult) Records() []*QueryRecord {
 
 // Next will query the next set of records.
 func (result *QueryResult) Next() (*QueryResult, error) {
+	return result.NextWithContext(context.Background())
+}
+
+// NextWithContext will query the next set of records using the context
+// for the request.  If the context is done, the context's error is returned.
+func (result *QueryResult) NextWithContext(ctx context.Context) (*QueryResult, error) {
 	if result.MoreRecords() == false {
 		return nil, errors.New("soql query result: no more records to query")
 	}
-	return result.resource.next(result.response.NextRecordsURL)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result.resource.next(ctx, result.response.NextRecordsURL)
 }
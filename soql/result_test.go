@@ -1,6 +1,8 @@
 package soql
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -408,3 +410,64 @@ func TestQueryResult_Next(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryResult_NextWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pages int
+	resource := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				pages++
+				if pages == 2 {
+					cancel()
+				}
+				resp := `
+				{
+					"done" : false,
+					"totalSize" : 6,
+					"nextRecordsUrl" : "/services/data/v20.0/query/01gD0000002HU6KIAW-2000",
+					"records" : 
+					[ 
+						{  
+							"attributes" : 
+							{    
+								"type" : "Account",    
+								"url" : "/services/data/v20.0/sobjects/Account/001D000000IRFmaIAH"  
+							},  
+							"Name" : "Test 1"
+						}
+					]
+				}`
+
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+	}
+	result := &QueryResult{
+		response: queryResponse{
+			NextRecordsURL: "/services/data/v20.0/query/01gD0000002HU6KIAW-1000",
+		},
+		resource: resource,
+	}
+
+	var err error
+	for result.MoreRecords() {
+		result, err = result.NextWithContext(ctx)
+		if err != nil {
+			break
+		}
+	}
+	if errors.Is(err, context.Canceled) == false {
+		t.Errorf("QueryResult.NextWithContext() error = %v, want %v", err, context.Canceled)
+	}
+	if pages != 2 {
+		t.Errorf("QueryResult.NextWithContext() pages = %d, want 2", pages)
+	}
+}
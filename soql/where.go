@@ -0,0 +1,171 @@
+package soql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	whereAnd = "AND"
+	whereOr  = "OR"
+	whereNot = "NOT"
+)
+
+// WhereCondition is a node of the where expression tree.  It is formed with
+// And, Or and Not, where the operands are the where expressions, like the
+// WhereClause from WhereEquals or another condition.  The conditions are not
+// changed when they are combined, so the parentheses are placed when the
+// expression is rendered.
+type WhereCondition struct {
+	operator string
+	operands []WhereExpression
+}
+
+// And will logical AND the expressions.  The nil expressions are skipped.
+func And(expressions ...WhereExpression) *WhereCondition {
+	return newWhereCondition(whereAnd, expressions)
+}
+
+// Or will logical OR the expressions.  The nil expressions are skipped.
+func Or(expressions ...WhereExpression) *WhereCondition {
+	return newWhereCondition(whereOr, expressions)
+}
+
+// Not will logical NOT the expression.
+func Not(expression WhereExpression) *WhereCondition {
+	return newWhereCondition(whereNot, []WhereExpression{expression})
+}
+
+func newWhereCondition(operator string, expressions []WhereExpression) *WhereCondition {
+	operands := make([]WhereExpression, 0, len(expressions))
+	for _, expression := range expressions {
+		if expression == nil {
+			continue
+		}
+		if condition, is := expression.(*WhereCondition); is && condition == nil {
+			continue
+		}
+		if clause, is := expression.(*WhereClause); is && clause == nil {
+			continue
+		}
+		operands = append(operands, expression)
+	}
+	return &WhereCondition{
+		operator: operator,
+		operands: operands,
+	}
+}
+
+// Expression will return the where expression.  An empty condition returns
+// an empty string.
+func (c *WhereCondition) Expression() string {
+	parts := c.parts()
+	switch {
+	case len(parts) == 0:
+		return ""
+	case c.operator == whereNot:
+		return fmt.Sprintf("%s %s", whereNot, c.group(parts[0]))
+	case len(parts) == 1:
+		return parts[0].expression
+	}
+	expressions := make([]string, len(parts))
+	for idx, part := range parts {
+		expressions[idx] = c.group(part)
+	}
+	return strings.Join(expressions, fmt.Sprintf(" %s ", c.operator))
+}
+
+// Clause returns the where clause.  An empty condition returns an empty
+// string.
+func (c *WhereCondition) Clause() string {
+	expression := c.Expression()
+	if expression == "" {
+		return ""
+	}
+	return fmt.Sprintf("WHERE %s", expression)
+}
+
+type wherePart struct {
+	operand    WhereExpression
+	expression string
+}
+
+// parts returns the operands that do not have an empty expression.
+func (c *WhereCondition) parts() []wherePart {
+	var parts []wherePart
+	for _, operand := range c.operands {
+		if expression := operand.Expression(); expression != "" {
+			parts = append(parts, wherePart{
+				operand:    operand,
+				expression: expression,
+			})
+		}
+	}
+	return parts
+}
+
+func (c *WhereCondition) group(part wherePart) string {
+	if c.grouped(part) {
+		return fmt.Sprintf("(%s)", part.expression)
+	}
+	return part.expression
+}
+
+// grouped returns true if the operand needs to be placed in parentheses.  A
+// condition is grouped in a condition with another operator, and a NOT is
+// only grouped in a NOT.  Other expressions are grouped if they have AND or OR
+// outside of parentheses and string literals.
+func (c *WhereCondition) grouped(part wherePart) bool {
+	if condition, is := part.operand.(*WhereCondition); is {
+		switch {
+		case condition.operator == whereNot:
+			return c.operator == whereNot
+		case len(condition.parts()) == 1:
+			return c.grouped(condition.parts()[0])
+		}
+		return condition.operator != c.operator
+	}
+	if c.operator == whereNot && strings.HasPrefix(strings.ToUpper(part.expression), whereNot+" ") {
+		return true
+	}
+	return isCompound(part.expression)
+}
+
+// isCompound returns true if the expression has an AND or OR outside of
+// parentheses and string literals.
+func isCompound(expression string) bool {
+	var depth int
+	var quoted bool
+	var word strings.Builder
+	for idx := 0; idx < len(expression); idx++ {
+		b := expression[idx]
+		switch {
+		case quoted:
+			switch b {
+			case '\\':
+				idx++
+			case '\'':
+				quoted = false
+			}
+			continue
+		case b == '\'':
+			quoted = true
+		case b == '(':
+			depth++
+		case b == ')':
+			depth--
+		case depth == 0 && (b == ' ' || b == '\t' || b == '\n'):
+			switch strings.ToUpper(word.String()) {
+			case whereAnd, whereOr:
+				return true
+			}
+			word.Reset()
+			continue
+		case depth == 0:
+			word.WriteByte(b)
+			continue
+		}
+		word.Reset()
+	}
+	return false
+}
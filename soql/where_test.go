@@ -0,0 +1,197 @@
+package soql
+
+import (
+	"testing"
+)
+
+func TestWhereCondition_Expression(t *testing.T) {
+	testWhere := func(where *WhereClause, err error) *WhereClause {
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return where
+	}
+	name := testWhere(WhereEquals("Name", "Acme"))
+	site := testWhere(WhereEquals("Site", "HQ"))
+	city := testWhere(WhereLike("BillingCity", "S%"))
+	rating := testWhere(WhereNotLike("Rating", "C%"))
+	compound := testWhere(WhereEquals("Type", "Partner"))
+	compound.Or(testWhere(WhereEquals("Type", "Customer")))
+	literal := testWhere(WhereEquals("Name", "A AND B OR (C"))
+
+	tests := []struct {
+		name  string
+		where *WhereCondition
+		want  string
+	}{
+		{
+			name:  "And",
+			where: And(name, site, city),
+			want:  "Name = 'Acme' AND Site = 'HQ' AND BillingCity LIKE 'S%'",
+		},
+		{
+			name:  "Or in And",
+			where: And(name, Or(site, city)),
+			want:  "Name = 'Acme' AND (Site = 'HQ' OR BillingCity LIKE 'S%')",
+		},
+		{
+			name:  "And in Or",
+			where: Or(And(name, site), city),
+			want:  "(Name = 'Acme' AND Site = 'HQ') OR BillingCity LIKE 'S%'",
+		},
+		{
+			name:  "And in And",
+			where: And(And(name, site), city),
+			want:  "Name = 'Acme' AND Site = 'HQ' AND BillingCity LIKE 'S%'",
+		},
+		{
+			name:  "Not",
+			where: And(Not(name), site),
+			want:  "NOT Name = 'Acme' AND Site = 'HQ'",
+		},
+		{
+			name:  "Not Or",
+			where: Not(Or(name, site)),
+			want:  "NOT (Name = 'Acme' OR Site = 'HQ')",
+		},
+		{
+			name:  "Not Not",
+			where: Not(Not(name)),
+			want:  "NOT (NOT Name = 'Acme')",
+		},
+		{
+			name:  "Not Like",
+			where: Not(rating),
+			want:  "NOT (NOT Rating LIKE 'C%')",
+		},
+		{
+			name:  "Single Operand",
+			where: And(Or(Or(name, site)), city),
+			want:  "(Name = 'Acme' OR Site = 'HQ') AND BillingCity LIKE 'S%'",
+		},
+		{
+			name:  "Compound Clause",
+			where: And(name, compound),
+			want:  "Name = 'Acme' AND (Type = 'Partner' OR Type = 'Customer')",
+		},
+		{
+			name:  "String Literal",
+			where: And(literal, site),
+			want:  "Name = 'A AND B OR (C' AND Site = 'HQ'",
+		},
+		{
+			name:  "Nil and Empty",
+			where: And(nil, (*WhereClause)(nil), Or(), name),
+			want:  "Name = 'Acme'",
+		},
+		{
+			name:  "Empty",
+			where: Or(),
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.where.Expression(); got != tt.want {
+				t.Errorf("WhereCondition.Expression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereCondition_Clause(t *testing.T) {
+	name, err := WhereEquals("Name", "Acme")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if got := And(name).Clause(); got != "WHERE Name = 'Acme'" {
+		t.Errorf("WhereCondition.Clause() = %v, want %v", got, "WHERE Name = 'Acme'")
+	}
+	if got := And().Clause(); got != "" {
+		t.Errorf("WhereCondition.Clause() = %v, want an empty string", got)
+	}
+
+	query, err := NewQuery(QueryInput{
+		ObjectType: "Account",
+		FieldList:  []string{"Id"},
+		Where:      Or(),
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := query.Format()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if got != "SELECT Id FROM Account" {
+		t.Errorf("Query.Format() = %v, want %v", got, "SELECT Id FROM Account")
+	}
+}
+
+func TestWhereIncludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		values   []string
+		includes string
+		excludes string
+		wantErr  bool
+	}{
+		{
+			name:     "Passing",
+			field:    "Interests__c",
+			values:   []string{"Golf;Chess", "Sailor's"},
+			includes: `Interests__c INCLUDES ('Golf;Chess','Sailor\'s')`,
+			excludes: `Interests__c EXCLUDES ('Golf;Chess','Sailor\'s')`,
+		},
+		{
+			name:    "No Field",
+			values:  []string{"Golf"},
+			wantErr: true,
+		},
+		{
+			name:    "No Values",
+			field:   "Interests__c",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			includes, err := WhereIncludes(tt.field, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WhereIncludes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			excludes, err := WhereExcludes(tt.field, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WhereExcludes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if includes.Expression() != tt.includes {
+				t.Errorf("WhereIncludes() = %v, want %v", includes.Expression(), tt.includes)
+			}
+			if excludes.Expression() != tt.excludes {
+				t.Errorf("WhereExcludes() = %v, want %v", excludes.Expression(), tt.excludes)
+			}
+		})
+	}
+}
+
+func TestWhereNotLike(t *testing.T) {
+	got, err := WhereNotLike("Name", "O'B%")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if want := `NOT Name LIKE 'O\'B%'`; got.Expression() != want {
+		t.Errorf("WhereNotLike() = %v, want %v", got.Expression(), want)
+	}
+	if _, err := WhereNotLike("", "A"); err == nil {
+		t.Error("WhereNotLike() expected an error for an empty field")
+	}
+	if _, err := WhereNotLike("Name", ""); err == nil {
+		t.Error("WhereNotLike() expected an error for an empty value")
+	}
+}
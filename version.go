@@ -0,0 +1,17 @@
+package sfdc
+
+// VersionPolicy is how the session will use the configuration's version
+// when it is opened.
+type VersionPolicy int
+
+const (
+	// VersionAsConfigured uses the configuration's version without checking
+	// the org.
+	VersionAsConfigured VersionPolicy = iota
+	// VersionLatest uses the highest version that the org supports.  The
+	// configuration's version is not required.
+	VersionLatest
+	// VersionVerify checks that the org supports the configuration's version
+	// and returns an error if it does not.
+	VersionVerify
+)